@@ -22,6 +22,147 @@ const (
 	SignatureAuthScopes = "signatureAuth.Scopes"
 )
 
+// Defines values for BounceNotificationBounceType.
+const (
+	Hard BounceNotificationBounceType = "hard"
+	Soft BounceNotificationBounceType = "soft"
+)
+
+// Defines values for BounceNotificationType.
+const (
+	Bounce    BounceNotificationType = "bounce"
+	Complaint BounceNotificationType = "complaint"
+	Delay     BounceNotificationType = "delay"
+	Delivery  BounceNotificationType = "delivery"
+)
+
+// Defines values for DependencyCheckStatus.
+const (
+	Down DependencyCheckStatus = "down"
+	Up   DependencyCheckStatus = "up"
+)
+
+// Defines values for MessageStatusChannel.
+const (
+	Email MessageStatusChannel = "email"
+	Sms   MessageStatusChannel = "sms"
+)
+
+// Defines values for ReadinessReportStatus.
+const (
+	NotReady ReadinessReportStatus = "not_ready"
+	Ready    ReadinessReportStatus = "ready"
+)
+
+// Defines values for RecipientStatusStatus.
+const (
+	BOUNCED    RecipientStatusStatus = "BOUNCED"
+	CANCELLED  RecipientStatusStatus = "CANCELLED"
+	COMPLAINED RecipientStatusStatus = "COMPLAINED"
+	DEFERRED   RecipientStatusStatus = "DEFERRED"
+	DELIVERED  RecipientStatusStatus = "DELIVERED"
+	FAILED     RecipientStatusStatus = "FAILED"
+	SCHEDULED  RecipientStatusStatus = "SCHEDULED"
+	SENT       RecipientStatusStatus = "SENT"
+	SUPPRESSED RecipientStatusStatus = "SUPPRESSED"
+)
+
+// Defines values for SmsRequestClass.
+const (
+	Otp      SmsRequestClass = "otp"
+	Standard SmsRequestClass = "standard"
+)
+
+// Defines values for SuppressionEntryReason.
+const (
+	SuppressionEntryReasonCOMPLAINT   SuppressionEntryReason = "COMPLAINT"
+	SuppressionEntryReasonHARDBOUNCE  SuppressionEntryReason = "HARD_BOUNCE"
+	SuppressionEntryReasonMANUAL      SuppressionEntryReason = "MANUAL"
+	SuppressionEntryReasonUNSUBSCRIBE SuppressionEntryReason = "UNSUBSCRIBE"
+)
+
+// Defines values for SuppressionRequestReason.
+const (
+	SuppressionRequestReasonCOMPLAINT   SuppressionRequestReason = "COMPLAINT"
+	SuppressionRequestReasonHARDBOUNCE  SuppressionRequestReason = "HARD_BOUNCE"
+	SuppressionRequestReasonMANUAL      SuppressionRequestReason = "MANUAL"
+	SuppressionRequestReasonUNSUBSCRIBE SuppressionRequestReason = "UNSUBSCRIBE"
+)
+
+// BounceNotification A bounce or complaint reported by a provider in JSON instead of as a delivery status notification.
+type BounceNotification struct {
+	// BounceType Only hard bounces suppress the recipient.
+	BounceType *BounceNotificationBounceType `json:"bounceType,omitempty"`
+	Diagnostic *string                       `json:"diagnostic,omitempty"`
+
+	// MessageId The Message-ID of the original message, without angle brackets.
+	MessageId *string                `json:"messageId,omitempty"`
+	Recipient openapi_types.Email    `json:"recipient"`
+	Type      BounceNotificationType `json:"type"`
+}
+
+// BounceNotificationBounceType Only hard bounces suppress the recipient.
+type BounceNotificationBounceType string
+
+// BounceNotificationType defines model for BounceNotification.Type.
+type BounceNotificationType string
+
+// ConfigReloadEvent defines model for ConfigReloadEvent.
+type ConfigReloadEvent struct {
+	At time.Time `json:"at"`
+
+	// Error Why the load failed. Absent on success.
+	Error *string `json:"error,omitempty"`
+
+	// Hash Hash of the loaded config. Absent when the load failed.
+	Hash *string `json:"hash,omitempty"`
+}
+
+// ConfigStatus defines model for ConfigStatus.
+type ConfigStatus struct {
+	// Config The active config with secrets replaced by `[REDACTED]`.
+	Config map[string]interface{} `json:"config"`
+
+	// Events Most recent load attempts, oldest first.
+	Events []ConfigReloadEvent `json:"events"`
+
+	// FailedReloads Rejected reloads since startup. The previous config stays active.
+	FailedReloads int `json:"failedReloads"`
+
+	// LastError Error of the most recent failed load, if any.
+	LastError   *string    `json:"lastError,omitempty"`
+	LastErrorAt *time.Time `json:"lastErrorAt,omitempty"`
+	Path        string     `json:"path"`
+
+	// Reloads Successful reloads since startup.
+	Reloads int           `json:"reloads"`
+	Version ConfigVersion `json:"version"`
+}
+
+// ConfigVersion defines model for ConfigVersion.
+type ConfigVersion struct {
+	// Hash SHA-256 of the config file contents.
+	Hash     string    `json:"hash"`
+	LoadedAt time.Time `json:"loadedAt"`
+}
+
+// DependencyCheck defines model for DependencyCheck.
+type DependencyCheck struct {
+	CheckedAt time.Time `json:"checkedAt"`
+
+	// Error Why the probe failed. Absent when the dependency is up.
+	Error     *string `json:"error,omitempty"`
+	Kind      string  `json:"kind"`
+	LatencyMs float64 `json:"latencyMs"`
+
+	// Name Dependency name, with addresses masked like in logs.
+	Name   string                `json:"name"`
+	Status DependencyCheckStatus `json:"status"`
+}
+
+// DependencyCheckStatus defines model for DependencyCheck.Status.
+type DependencyCheckStatus string
+
 // EmailContact defines model for EmailContact.
 type EmailContact struct {
 	Address openapi_types.Email `json:"address"`
@@ -32,7 +173,15 @@ type EmailContact struct {
 type EmailRequest struct {
 	Content *EmailRequest_Content `json:"content,omitempty"`
 	From    EmailContact          `json:"from"`
-	Subject string                `json:"subject"`
+
+	// Scope Suppression scope of the email, e.g. `newsletter`. Recipients suppressed globally or in this scope are skipped. Emails to a single recipient with a scope carry one-click `List-Unsubscribe` headers when `unsubscribe` is configured.
+	Scope *string `json:"scope,omitempty"`
+
+	// SendAt Send the email at this time instead of immediately, up to a year ahead. Scheduled messages are kept in the server's storage, so they survive restarts, and can be cancelled with `DELETE /v3/messages/{messageId}` until they are sent. Suppressions are checked when the message is sent. Times in the past send immediately.
+	SendAt *time.Time `json:"sendAt,omitempty"`
+
+	// Subject Must not contain line breaks.
+	Subject string `json:"subject"`
 
 	// To A single recipient or an array of recipients.
 	To EmailRequest_To `json:"to"`
@@ -76,16 +225,112 @@ type EmailRequest_To struct {
 	union json.RawMessage
 }
 
+// EmailSuccessResponse defines model for EmailSuccessResponse.
+type EmailSuccessResponse struct {
+	Data    *map[string]interface{} `json:"data,omitempty"`
+	Message string                  `json:"message"`
+
+	// MessageId The Message-ID header of the sent message, without angle brackets.
+	MessageId string `json:"messageId"`
+
+	// Recipients The status of each recipient, `SENT` or `SUPPRESSED`, or `SCHEDULED` for scheduled emails.
+	Recipients []RecipientStatus `json:"recipients"`
+
+	// SendAt When the email will be sent. Only set for scheduled emails.
+	SendAt  *time.Time `json:"sendAt,omitempty"`
+	Success bool       `json:"success"`
+}
+
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
 	Error struct {
-		Code    string    `json:"code"`
+		// Code Stable, machine-readable error code:
+		// - `MISSING_AUTH_HEADERS`: `X-Client-Id`, `X-Timestamp` or `Authorization` is missing.
+		// - `INVALID_TIMESTAMP`: `X-Timestamp` is not an RFC 3339 date-time.
+		// - `TIMESTAMP_EXPIRED`: `X-Timestamp` is more than 5 minutes from server time.
+		// - `UNKNOWN_CLIENT`: No service is registered for `X-Client-Id`.
+		// - `INVALID_AUTH_HEADER`: `Authorization` is not `Signature <base64_signature>`, or does not carry the bounce webhook secret.
+		// - `INVALID_SIGNATURE_ENCODING`: The signature is not valid Base64.
+		// - `SIGNATURE_INVALID`: The signature does not match the canonical request.
+		// - `INVALID_BODY`: The request body is not valid JSON for the schema.
+		// - `PAYLOAD_TOO_LARGE`: The request body exceeds the configured size limit.
+		// - `INVALID_PARAMETER`: A header or parameter is missing or malformed.
+		// - `NO_RECIPIENTS`: No usable recipient could be extracted from `to`.
+		// - `FORBIDDEN`: The authenticated service may not use this endpoint.
+		// - `NOT_FOUND` / `METHOD_NOT_ALLOWED`: Unknown route or verb.
+		// - `DELIVERY_FAILED`: The upstream provider rejected or failed the delivery.
+		// - `NOT_SCHEDULED`: The message is not waiting to be sent, so it cannot be cancelled.
+		// - `INTERNAL_ERROR`: An unexpected server-side failure.
+		Code string `json:"code"`
+
+		// Details Field-level details, formatted as `<field>: <problem>` where applicable.
 		Details *[]string `json:"details,omitempty"`
 		Message string    `json:"message"`
 	} `json:"error"`
 	Success bool `json:"success"`
 }
 
+// LivenessStatus defines model for LivenessStatus.
+type LivenessStatus struct {
+	Status    string    `json:"status"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// MessageStatus defines model for MessageStatus.
+type MessageStatus struct {
+	Channel    MessageStatusChannel `json:"channel"`
+	CreatedAt  time.Time            `json:"createdAt"`
+	From       *string              `json:"from,omitempty"`
+	Id         string               `json:"id"`
+	Recipients []RecipientStatus    `json:"recipients"`
+}
+
+// MessageStatusChannel defines model for MessageStatus.Channel.
+type MessageStatusChannel string
+
+// ReadinessReport defines model for ReadinessReport.
+type ReadinessReport struct {
+	Checks []DependencyCheck     `json:"checks"`
+	Status ReadinessReportStatus `json:"status"`
+
+	// Timestamp When the dependencies were last probed.
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// ReadinessReportStatus defines model for ReadinessReport.Status.
+type ReadinessReportStatus string
+
+// RecipientStatus defines model for RecipientStatus.
+type RecipientStatus struct {
+	Address string `json:"address"`
+
+	// Detail The diagnostic of the report that set the status.
+	Detail *string `json:"detail,omitempty"`
+
+	// Status - `SCHEDULED`: Waiting to be sent at the requested time.
+	// - `SENT`: Accepted by the provider.
+	// - `DELIVERED`: A delivery report confirmed delivery.
+	// - `DEFERRED`: Delivery is delayed and may still succeed.
+	// - `BOUNCED`: The recipient's server rejected the message. Hard bounces also suppress the recipient.
+	// - `COMPLAINED`: The recipient reported the message as spam, which suppresses the recipient.
+	// - `SUPPRESSED`: Not sent, because the recipient is on the suppression list.
+	// - `CANCELLED`: Not sent, because the scheduled message was cancelled.
+	// - `FAILED`: Not sent, because every attempt to send the scheduled message failed.
+	Status    RecipientStatusStatus `json:"status"`
+	UpdatedAt time.Time             `json:"updatedAt"`
+}
+
+// RecipientStatusStatus - `SCHEDULED`: Waiting to be sent at the requested time.
+// - `SENT`: Accepted by the provider.
+// - `DELIVERED`: A delivery report confirmed delivery.
+// - `DEFERRED`: Delivery is delayed and may still succeed.
+// - `BOUNCED`: The recipient's server rejected the message. Hard bounces also suppress the recipient.
+// - `COMPLAINED`: The recipient reported the message as spam, which suppresses the recipient.
+// - `SUPPRESSED`: Not sent, because the recipient is on the suppression list.
+// - `CANCELLED`: Not sent, because the scheduled message was cancelled.
+// - `FAILED`: Not sent, because every attempt to send the scheduled message failed.
+type RecipientStatusStatus string
+
 // SmsRecipient defines model for SmsRecipient.
 type SmsRecipient struct {
 	union json.RawMessage
@@ -96,20 +341,34 @@ type SmsRecipient0 = string
 
 // SmsRecipient1 defines model for .
 type SmsRecipient1 struct {
-	// Country ISO 3166-1 alpha-2 country code.
+	// Country ISO 3166-1 alpha-2 country code. Also sets the recipient's time zone for quiet hours. For countries with several, the SMS is deferred until the window has ended in all of them, or follows the capital's when no time is outside the window in every zone.
 	Country string `json:"country"`
 	Phone   string `json:"phone"`
+
+	// TimeZone IANA time zone of the recipient for quiet hours, overriding the one of `country`.
+	TimeZone *string `json:"timeZone,omitempty"`
 }
 
 // SmsRequest defines model for SmsRequest.
 type SmsRequest struct {
-	Content    *SmsRequest_Content `json:"content,omitempty"`
-	SenderName string              `json:"senderName"`
+	// Class `otp` marks one-time passwords and other messages the recipient is waiting for, which bypass the service's quiet hours. `standard` messages that would reach a recipient during quiet hours are scheduled for the end of them.
+	Class   *SmsRequestClass    `json:"class,omitempty"`
+	Content *SmsRequest_Content `json:"content,omitempty"`
+
+	// Scope Suppression scope of the SMS. Recipients suppressed globally or in this scope are skipped.
+	Scope *string `json:"scope,omitempty"`
+
+	// SendAt Send the SMS at this time instead of immediately, up to a year ahead. Scheduled messages are kept in the server's storage, so they survive restarts, and can be cancelled with `DELETE /v3/messages/{messageId}` until they are sent. Suppressions are checked when the message is sent. Times in the past send immediately.
+	SendAt     *time.Time `json:"sendAt,omitempty"`
+	SenderName string     `json:"senderName"`
 
 	// To A single recipient or an array of recipients.
 	To SmsRequest_To `json:"to"`
 }
 
+// SmsRequestClass `otp` marks one-time passwords and other messages the recipient is waiting for, which bypass the service's quiet hours. `standard` messages that would reach a recipient during quiet hours are scheduled for the end of them.
+type SmsRequestClass string
+
 // SmsRequestContent0 defines model for .
 type SmsRequestContent0 struct {
 	Body string `json:"body"`
@@ -140,11 +399,20 @@ type SmsRequest_To struct {
 type SmsSuccessResponse struct {
 	Data    *map[string]interface{} `json:"data,omitempty"`
 	Message string                  `json:"message"`
-	Meta    *struct {
+
+	// MessageId The ID of a scheduled SMS, for its status and for cancelling it. Only set for SMS scheduled with `sendAt` or deferred by quiet hours.
+	MessageId *string `json:"messageId,omitempty"`
+	Meta      *struct {
 		Cost     *float32 `json:"cost,omitempty"`
 		Currency *string  `json:"currency,omitempty"`
 	} `json:"meta,omitempty"`
-	Success bool `json:"success"`
+
+	// Recipients The status of each recipient, `SENT` or `SUPPRESSED`, or `SCHEDULED` for scheduled SMS.
+	Recipients []RecipientStatus `json:"recipients"`
+
+	// SendAt When the SMS will be sent. Only set for scheduled SMS, and not when quiet hours defer recipients to different times.
+	SendAt  *time.Time `json:"sendAt,omitempty"`
+	Success bool       `json:"success"`
 }
 
 // SuccessResponse defines model for SuccessResponse.
@@ -154,12 +422,58 @@ type SuccessResponse struct {
 	Success bool                    `json:"success"`
 }
 
+// SuppressionEntry defines model for SuppressionEntry.
+type SuppressionEntry struct {
+	CreatedAt time.Time              `json:"createdAt"`
+	Detail    *string                `json:"detail,omitempty"`
+	Reason    SuppressionEntryReason `json:"reason"`
+
+	// Recipient The recipient, normalized (lower-case address, phone number without separators).
+	Recipient string `json:"recipient"`
+	Scope     string `json:"scope"`
+
+	// Service The service that added the entry. Absent for entries added by bounces and unsubscribe links.
+	Service *string `json:"service,omitempty"`
+}
+
+// SuppressionEntryReason defines model for SuppressionEntry.Reason.
+type SuppressionEntryReason string
+
+// SuppressionList defines model for SuppressionList.
+type SuppressionList struct {
+	Entries []SuppressionEntry `json:"entries"`
+}
+
+// SuppressionRequest defines model for SuppressionRequest.
+type SuppressionRequest struct {
+	Detail *string                   `json:"detail,omitempty"`
+	Reason *SuppressionRequestReason `json:"reason,omitempty"`
+
+	// Recipient An email address or an E.164 phone number.
+	Recipient string `json:"recipient"`
+
+	// Scope `global` blocks every send; other scopes only sends made with that `scope`.
+	Scope *string `json:"scope,omitempty"`
+}
+
+// SuppressionRequestReason defines model for SuppressionRequest.Reason.
+type SuppressionRequestReason string
+
 // ClientIdHeader defines model for ClientIdHeader.
 type ClientIdHeader = string
 
 // TimestampHeader defines model for TimestampHeader.
 type TimestampHeader = time.Time
 
+// GetAdminConfigParams defines parameters for GetAdminConfig.
+type GetAdminConfigParams struct {
+	// XClientId The unique ID assigned to your service.
+	XClientId ClientIdHeader `json:"X-Client-Id"`
+
+	// XTimestamp ISO 8601 timestamp. Requests older than 5 minutes will be rejected.
+	XTimestamp TimestampHeader `json:"X-Timestamp"`
+}
+
 // PostV3EmailParams defines parameters for PostV3Email.
 type PostV3EmailParams struct {
 	// XClientId The unique ID assigned to your service.
@@ -169,6 +483,24 @@ type PostV3EmailParams struct {
 	XTimestamp TimestampHeader `json:"X-Timestamp"`
 }
 
+// DeleteV3MessagesMessageIdParams defines parameters for DeleteV3MessagesMessageId.
+type DeleteV3MessagesMessageIdParams struct {
+	// XClientId The unique ID assigned to your service.
+	XClientId ClientIdHeader `json:"X-Client-Id"`
+
+	// XTimestamp ISO 8601 timestamp. Requests older than 5 minutes will be rejected.
+	XTimestamp TimestampHeader `json:"X-Timestamp"`
+}
+
+// GetV3MessagesMessageIdParams defines parameters for GetV3MessagesMessageId.
+type GetV3MessagesMessageIdParams struct {
+	// XClientId The unique ID assigned to your service.
+	XClientId ClientIdHeader `json:"X-Client-Id"`
+
+	// XTimestamp ISO 8601 timestamp. Requests older than 5 minutes will be rejected.
+	XTimestamp TimestampHeader `json:"X-Timestamp"`
+}
+
 // PostV3SmsParams defines parameters for PostV3Sms.
 type PostV3SmsParams struct {
 	// XClientId The unique ID assigned to your service.
@@ -178,12 +510,50 @@ type PostV3SmsParams struct {
 	XTimestamp TimestampHeader `json:"X-Timestamp"`
 }
 
+// GetV3SuppressionsParams defines parameters for GetV3Suppressions.
+type GetV3SuppressionsParams struct {
+	// Scope Only list entries in this scope. All scopes are listed when omitted.
+	Scope *string `form:"scope,omitempty" json:"scope,omitempty"`
+
+	// XClientId The unique ID assigned to your service.
+	XClientId ClientIdHeader `json:"X-Client-Id"`
+
+	// XTimestamp ISO 8601 timestamp. Requests older than 5 minutes will be rejected.
+	XTimestamp TimestampHeader `json:"X-Timestamp"`
+}
+
+// PostV3SuppressionsParams defines parameters for PostV3Suppressions.
+type PostV3SuppressionsParams struct {
+	// XClientId The unique ID assigned to your service.
+	XClientId ClientIdHeader `json:"X-Client-Id"`
+
+	// XTimestamp ISO 8601 timestamp. Requests older than 5 minutes will be rejected.
+	XTimestamp TimestampHeader `json:"X-Timestamp"`
+}
+
+// DeleteV3SuppressionsRecipientParams defines parameters for DeleteV3SuppressionsRecipient.
+type DeleteV3SuppressionsRecipientParams struct {
+	Scope *string `form:"scope,omitempty" json:"scope,omitempty"`
+
+	// XClientId The unique ID assigned to your service.
+	XClientId ClientIdHeader `json:"X-Client-Id"`
+
+	// XTimestamp ISO 8601 timestamp. Requests older than 5 minutes will be rejected.
+	XTimestamp TimestampHeader `json:"X-Timestamp"`
+}
+
 // PostV3EmailJSONRequestBody defines body for PostV3Email for application/json ContentType.
 type PostV3EmailJSONRequestBody = EmailRequest
 
 // PostV3SmsJSONRequestBody defines body for PostV3Sms for application/json ContentType.
 type PostV3SmsJSONRequestBody = SmsRequest
 
+// PostV3SuppressionsJSONRequestBody defines body for PostV3Suppressions for application/json ContentType.
+type PostV3SuppressionsJSONRequestBody = SuppressionRequest
+
+// PostWebhooksBouncesJSONRequestBody defines body for PostWebhooksBounces for application/json ContentType.
+type PostWebhooksBouncesJSONRequestBody = BounceNotification
+
 // AsEmailRequestContent0 returns the union data inside the EmailRequest_Content as a EmailRequestContent0
 func (t EmailRequest_Content) AsEmailRequestContent0() (EmailRequestContent0, error) {
 	var body EmailRequestContent0
@@ -655,18 +1025,67 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// GetAdminConfig request
+	GetAdminConfig(ctx context.Context, params *GetAdminConfigParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetHealth request
 	GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// GetHealthLive request
+	GetHealthLive(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetHealthReady request
+	GetHealthReady(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetUnsubscribeToken request
+	GetUnsubscribeToken(ctx context.Context, token string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostUnsubscribeToken request
+	PostUnsubscribeToken(ctx context.Context, token string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// PostV3EmailWithBody request with any body
 	PostV3EmailWithBody(ctx context.Context, params *PostV3EmailParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	PostV3Email(ctx context.Context, params *PostV3EmailParams, body PostV3EmailJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// DeleteV3MessagesMessageId request
+	DeleteV3MessagesMessageId(ctx context.Context, messageId string, params *DeleteV3MessagesMessageIdParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetV3MessagesMessageId request
+	GetV3MessagesMessageId(ctx context.Context, messageId string, params *GetV3MessagesMessageIdParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// PostV3SmsWithBody request with any body
 	PostV3SmsWithBody(ctx context.Context, params *PostV3SmsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	PostV3Sms(ctx context.Context, params *PostV3SmsParams, body PostV3SmsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetV3Suppressions request
+	GetV3Suppressions(ctx context.Context, params *GetV3SuppressionsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostV3SuppressionsWithBody request with any body
+	PostV3SuppressionsWithBody(ctx context.Context, params *PostV3SuppressionsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostV3Suppressions(ctx context.Context, params *PostV3SuppressionsParams, body PostV3SuppressionsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteV3SuppressionsRecipient request
+	DeleteV3SuppressionsRecipient(ctx context.Context, recipient string, params *DeleteV3SuppressionsRecipientParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostWebhooksBouncesWithBody request with any body
+	PostWebhooksBouncesWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostWebhooksBounces(ctx context.Context, body PostWebhooksBouncesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetAdminConfig(ctx context.Context, params *GetAdminConfigParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminConfigRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
 func (c *Client) GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -681,8 +1100,8 @@ func (c *Client) GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (
 	return c.Client.Do(req)
 }
 
-func (c *Client) PostV3EmailWithBody(ctx context.Context, params *PostV3EmailParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostV3EmailRequestWithBody(c.Server, params, contentType, body)
+func (c *Client) GetHealthLive(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetHealthLiveRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -693,8 +1112,8 @@ func (c *Client) PostV3EmailWithBody(ctx context.Context, params *PostV3EmailPar
 	return c.Client.Do(req)
 }
 
-func (c *Client) PostV3Email(ctx context.Context, params *PostV3EmailParams, body PostV3EmailJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostV3EmailRequest(c.Server, params, body)
+func (c *Client) GetHealthReady(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetHealthReadyRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -705,8 +1124,8 @@ func (c *Client) PostV3Email(ctx context.Context, params *PostV3EmailParams, bod
 	return c.Client.Do(req)
 }
 
-func (c *Client) PostV3SmsWithBody(ctx context.Context, params *PostV3SmsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostV3SmsRequestWithBody(c.Server, params, contentType, body)
+func (c *Client) GetUnsubscribeToken(ctx context.Context, token string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetUnsubscribeTokenRequest(c.Server, token)
 	if err != nil {
 		return nil, err
 	}
@@ -717,8 +1136,8 @@ func (c *Client) PostV3SmsWithBody(ctx context.Context, params *PostV3SmsParams,
 	return c.Client.Do(req)
 }
 
-func (c *Client) PostV3Sms(ctx context.Context, params *PostV3SmsParams, body PostV3SmsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostV3SmsRequest(c.Server, params, body)
+func (c *Client) PostUnsubscribeToken(ctx context.Context, token string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostUnsubscribeTokenRequest(c.Server, token)
 	if err != nil {
 		return nil, err
 	}
@@ -729,69 +1148,173 @@ func (c *Client) PostV3Sms(ctx context.Context, params *PostV3SmsParams, body Po
 	return c.Client.Do(req)
 }
 
-// NewGetHealthRequest generates requests for GetHealth
-func NewGetHealthRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) PostV3EmailWithBody(ctx context.Context, params *PostV3EmailParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostV3EmailRequestWithBody(c.Server, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/health")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) PostV3Email(ctx context.Context, params *PostV3EmailParams, body PostV3EmailJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostV3EmailRequest(c.Server, params, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+func (c *Client) DeleteV3MessagesMessageId(ctx context.Context, messageId string, params *DeleteV3MessagesMessageIdParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteV3MessagesMessageIdRequest(c.Server, messageId, params)
 	if err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewPostV3EmailRequest calls the generic PostV3Email builder with application/json body
-func NewPostV3EmailRequest(server string, params *PostV3EmailParams, body PostV3EmailJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+func (c *Client) GetV3MessagesMessageId(ctx context.Context, messageId string, params *GetV3MessagesMessageIdParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetV3MessagesMessageIdRequest(c.Server, messageId, params)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewPostV3EmailRequestWithBody(server, params, "application/json", bodyReader)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewPostV3EmailRequestWithBody generates requests for PostV3Email with any type of body
-func NewPostV3EmailRequestWithBody(server string, params *PostV3EmailParams, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) PostV3SmsWithBody(ctx context.Context, params *PostV3SmsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostV3SmsRequestWithBody(c.Server, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/v3/email")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) PostV3Sms(ctx context.Context, params *PostV3SmsParams, body PostV3SmsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostV3SmsRequest(c.Server, params, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
+func (c *Client) GetV3Suppressions(ctx context.Context, params *GetV3SuppressionsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetV3SuppressionsRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req.Header.Add("Content-Type", contentType)
+func (c *Client) PostV3SuppressionsWithBody(ctx context.Context, params *PostV3SuppressionsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostV3SuppressionsRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostV3Suppressions(ctx context.Context, params *PostV3SuppressionsParams, body PostV3SuppressionsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostV3SuppressionsRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteV3SuppressionsRecipient(ctx context.Context, recipient string, params *DeleteV3SuppressionsRecipientParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteV3SuppressionsRecipientRequest(c.Server, recipient, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostWebhooksBouncesWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostWebhooksBouncesRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostWebhooksBounces(ctx context.Context, body PostWebhooksBouncesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostWebhooksBouncesRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetAdminConfigRequest generates requests for GetAdminConfig
+func NewGetAdminConfigRequest(server string, params *GetAdminConfigParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/config")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
 
 	if params != nil {
 
@@ -818,19 +1341,168 @@ func NewPostV3EmailRequestWithBody(server string, params *PostV3EmailParams, con
 	return req, nil
 }
 
-// NewPostV3SmsRequest calls the generic PostV3Sms builder with application/json body
-func NewPostV3SmsRequest(server string, params *PostV3SmsParams, body PostV3SmsJSONRequestBody) (*http.Request, error) {
+// NewGetHealthRequest generates requests for GetHealth
+func NewGetHealthRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/health")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetHealthLiveRequest generates requests for GetHealthLive
+func NewGetHealthLiveRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/health/live")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetHealthReadyRequest generates requests for GetHealthReady
+func NewGetHealthReadyRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/health/ready")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetUnsubscribeTokenRequest generates requests for GetUnsubscribeToken
+func NewGetUnsubscribeTokenRequest(server string, token string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationPath, token)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/unsubscribe/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostUnsubscribeTokenRequest generates requests for PostUnsubscribeToken
+func NewPostUnsubscribeTokenRequest(server string, token string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationPath, token)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/unsubscribe/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostV3EmailRequest calls the generic PostV3Email builder with application/json body
+func NewPostV3EmailRequest(server string, params *PostV3EmailParams, body PostV3EmailJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPostV3SmsRequestWithBody(server, params, "application/json", bodyReader)
+	return NewPostV3EmailRequestWithBody(server, params, "application/json", bodyReader)
 }
 
-// NewPostV3SmsRequestWithBody generates requests for PostV3Sms with any type of body
-func NewPostV3SmsRequestWithBody(server string, params *PostV3SmsParams, contentType string, body io.Reader) (*http.Request, error) {
+// NewPostV3EmailRequestWithBody generates requests for PostV3Email with any type of body
+func NewPostV3EmailRequestWithBody(server string, params *PostV3EmailParams, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -838,7 +1510,7 @@ func NewPostV3SmsRequestWithBody(server string, params *PostV3SmsParams, content
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/v3/sms")
+	operationPath := fmt.Sprintf("/v3/email")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -880,272 +1552,1524 @@ func NewPostV3SmsRequestWithBody(server string, params *PostV3SmsParams, content
 	return req, nil
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
+// NewDeleteV3MessagesMessageIdRequest generates requests for DeleteV3MessagesMessageId
+func NewDeleteV3MessagesMessageIdRequest(server string, messageId string, params *DeleteV3MessagesMessageIdParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "messageId", runtime.ParamLocationPath, messageId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v3/messages/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Client-Id", runtime.ParamLocationHeader, params.XClientId)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Client-Id", headerParam0)
+
+		var headerParam1 string
+
+		headerParam1, err = runtime.StyleParamWithLocation("simple", false, "X-Timestamp", runtime.ParamLocationHeader, params.XTimestamp)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Timestamp", headerParam1)
+
+	}
+
+	return req, nil
+}
+
+// NewGetV3MessagesMessageIdRequest generates requests for GetV3MessagesMessageId
+func NewGetV3MessagesMessageIdRequest(server string, messageId string, params *GetV3MessagesMessageIdParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "messageId", runtime.ParamLocationPath, messageId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v3/messages/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Client-Id", runtime.ParamLocationHeader, params.XClientId)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Client-Id", headerParam0)
+
+		var headerParam1 string
+
+		headerParam1, err = runtime.StyleParamWithLocation("simple", false, "X-Timestamp", runtime.ParamLocationHeader, params.XTimestamp)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Timestamp", headerParam1)
+
+	}
+
+	return req, nil
+}
+
+// NewPostV3SmsRequest calls the generic PostV3Sms builder with application/json body
+func NewPostV3SmsRequest(server string, params *PostV3SmsParams, body PostV3SmsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostV3SmsRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewPostV3SmsRequestWithBody generates requests for PostV3Sms with any type of body
+func NewPostV3SmsRequestWithBody(server string, params *PostV3SmsParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v3/sms")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Client-Id", runtime.ParamLocationHeader, params.XClientId)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Client-Id", headerParam0)
+
+		var headerParam1 string
+
+		headerParam1, err = runtime.StyleParamWithLocation("simple", false, "X-Timestamp", runtime.ParamLocationHeader, params.XTimestamp)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Timestamp", headerParam1)
+
+	}
+
+	return req, nil
+}
+
+// NewGetV3SuppressionsRequest generates requests for GetV3Suppressions
+func NewGetV3SuppressionsRequest(server string, params *GetV3SuppressionsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v3/suppressions")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Scope != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "scope", runtime.ParamLocationQuery, *params.Scope); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Client-Id", runtime.ParamLocationHeader, params.XClientId)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Client-Id", headerParam0)
+
+		var headerParam1 string
+
+		headerParam1, err = runtime.StyleParamWithLocation("simple", false, "X-Timestamp", runtime.ParamLocationHeader, params.XTimestamp)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Timestamp", headerParam1)
+
+	}
+
+	return req, nil
+}
+
+// NewPostV3SuppressionsRequest calls the generic PostV3Suppressions builder with application/json body
+func NewPostV3SuppressionsRequest(server string, params *PostV3SuppressionsParams, body PostV3SuppressionsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostV3SuppressionsRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewPostV3SuppressionsRequestWithBody generates requests for PostV3Suppressions with any type of body
+func NewPostV3SuppressionsRequestWithBody(server string, params *PostV3SuppressionsParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v3/suppressions")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Client-Id", runtime.ParamLocationHeader, params.XClientId)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Client-Id", headerParam0)
+
+		var headerParam1 string
+
+		headerParam1, err = runtime.StyleParamWithLocation("simple", false, "X-Timestamp", runtime.ParamLocationHeader, params.XTimestamp)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Timestamp", headerParam1)
+
+	}
+
+	return req, nil
+}
+
+// NewDeleteV3SuppressionsRecipientRequest generates requests for DeleteV3SuppressionsRecipient
+func NewDeleteV3SuppressionsRecipientRequest(server string, recipient string, params *DeleteV3SuppressionsRecipientParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "recipient", runtime.ParamLocationPath, recipient)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v3/suppressions/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Scope != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "scope", runtime.ParamLocationQuery, *params.Scope); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Client-Id", runtime.ParamLocationHeader, params.XClientId)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Client-Id", headerParam0)
+
+		var headerParam1 string
+
+		headerParam1, err = runtime.StyleParamWithLocation("simple", false, "X-Timestamp", runtime.ParamLocationHeader, params.XTimestamp)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("X-Timestamp", headerParam1)
+
+	}
+
+	return req, nil
+}
+
+// NewPostWebhooksBouncesRequest calls the generic PostWebhooksBounces builder with application/json body
+func NewPostWebhooksBouncesRequest(server string, body PostWebhooksBouncesJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostWebhooksBouncesRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostWebhooksBouncesRequestWithBody generates requests for PostWebhooksBounces with any type of body
+func NewPostWebhooksBouncesRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/webhooks/bounces")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetAdminConfigWithResponse request
+	GetAdminConfigWithResponse(ctx context.Context, params *GetAdminConfigParams, reqEditors ...RequestEditorFn) (*GetAdminConfigResponse, error)
+
+	// GetHealthWithResponse request
+	GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error)
+
+	// GetHealthLiveWithResponse request
+	GetHealthLiveWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthLiveResponse, error)
+
+	// GetHealthReadyWithResponse request
+	GetHealthReadyWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthReadyResponse, error)
+
+	// GetUnsubscribeTokenWithResponse request
+	GetUnsubscribeTokenWithResponse(ctx context.Context, token string, reqEditors ...RequestEditorFn) (*GetUnsubscribeTokenResponse, error)
+
+	// PostUnsubscribeTokenWithResponse request
+	PostUnsubscribeTokenWithResponse(ctx context.Context, token string, reqEditors ...RequestEditorFn) (*PostUnsubscribeTokenResponse, error)
+
+	// PostV3EmailWithBodyWithResponse request with any body
+	PostV3EmailWithBodyWithResponse(ctx context.Context, params *PostV3EmailParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostV3EmailResponse, error)
+
+	PostV3EmailWithResponse(ctx context.Context, params *PostV3EmailParams, body PostV3EmailJSONRequestBody, reqEditors ...RequestEditorFn) (*PostV3EmailResponse, error)
+
+	// DeleteV3MessagesMessageIdWithResponse request
+	DeleteV3MessagesMessageIdWithResponse(ctx context.Context, messageId string, params *DeleteV3MessagesMessageIdParams, reqEditors ...RequestEditorFn) (*DeleteV3MessagesMessageIdResponse, error)
+
+	// GetV3MessagesMessageIdWithResponse request
+	GetV3MessagesMessageIdWithResponse(ctx context.Context, messageId string, params *GetV3MessagesMessageIdParams, reqEditors ...RequestEditorFn) (*GetV3MessagesMessageIdResponse, error)
+
+	// PostV3SmsWithBodyWithResponse request with any body
+	PostV3SmsWithBodyWithResponse(ctx context.Context, params *PostV3SmsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostV3SmsResponse, error)
+
+	PostV3SmsWithResponse(ctx context.Context, params *PostV3SmsParams, body PostV3SmsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostV3SmsResponse, error)
+
+	// GetV3SuppressionsWithResponse request
+	GetV3SuppressionsWithResponse(ctx context.Context, params *GetV3SuppressionsParams, reqEditors ...RequestEditorFn) (*GetV3SuppressionsResponse, error)
+
+	// PostV3SuppressionsWithBodyWithResponse request with any body
+	PostV3SuppressionsWithBodyWithResponse(ctx context.Context, params *PostV3SuppressionsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostV3SuppressionsResponse, error)
+
+	PostV3SuppressionsWithResponse(ctx context.Context, params *PostV3SuppressionsParams, body PostV3SuppressionsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostV3SuppressionsResponse, error)
+
+	// DeleteV3SuppressionsRecipientWithResponse request
+	DeleteV3SuppressionsRecipientWithResponse(ctx context.Context, recipient string, params *DeleteV3SuppressionsRecipientParams, reqEditors ...RequestEditorFn) (*DeleteV3SuppressionsRecipientResponse, error)
+
+	// PostWebhooksBouncesWithBodyWithResponse request with any body
+	PostWebhooksBouncesWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostWebhooksBouncesResponse, error)
+
+	PostWebhooksBouncesWithResponse(ctx context.Context, body PostWebhooksBouncesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostWebhooksBouncesResponse, error)
+}
+
+type GetAdminConfigResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ConfigStatus
+	JSON401      *ErrorResponse
+	JSON403      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAdminConfigResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminConfigResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetHealthResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *LivenessStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r GetHealthResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetHealthResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetHealthLiveResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *LivenessStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r GetHealthLiveResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetHealthLiveResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetHealthReadyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReadinessReport
+	JSON503      *ReadinessReport
+}
+
+// Status returns HTTPResponse.Status
+func (r GetHealthReadyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetHealthReadyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetUnsubscribeTokenResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetUnsubscribeTokenResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetUnsubscribeTokenResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostUnsubscribeTokenResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostUnsubscribeTokenResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostUnsubscribeTokenResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostV3EmailResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON202      *EmailSuccessResponse
+	JSON400      *ErrorResponse
+	JSON401      *ErrorResponse
+	JSON413      *ErrorResponse
+	JSON500      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostV3EmailResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostV3EmailResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteV3MessagesMessageIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SuccessResponse
+	JSON401      *ErrorResponse
+	JSON404      *ErrorResponse
+	JSON409      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteV3MessagesMessageIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteV3MessagesMessageIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetV3MessagesMessageIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *MessageStatus
+	JSON401      *ErrorResponse
+	JSON404      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetV3MessagesMessageIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetV3MessagesMessageIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostV3SmsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON202      *SmsSuccessResponse
+	JSON400      *ErrorResponse
+	JSON401      *ErrorResponse
+	JSON413      *ErrorResponse
+	JSON500      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostV3SmsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostV3SmsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetV3SuppressionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SuppressionList
+	JSON401      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetV3SuppressionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetV3SuppressionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostV3SuppressionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *SuppressionEntry
+	JSON400      *ErrorResponse
+	JSON401      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostV3SuppressionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostV3SuppressionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteV3SuppressionsRecipientResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SuccessResponse
+	JSON401      *ErrorResponse
+	JSON403      *ErrorResponse
+	JSON404      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteV3SuppressionsRecipientResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteV3SuppressionsRecipientResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostWebhooksBouncesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON202      *SuccessResponse
+	JSON400      *ErrorResponse
+	JSON401      *ErrorResponse
+	JSON404      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostWebhooksBouncesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostWebhooksBouncesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetAdminConfigWithResponse request returning *GetAdminConfigResponse
+func (c *ClientWithResponses) GetAdminConfigWithResponse(ctx context.Context, params *GetAdminConfigParams, reqEditors ...RequestEditorFn) (*GetAdminConfigResponse, error) {
+	rsp, err := c.GetAdminConfig(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminConfigResponse(rsp)
+}
+
+// GetHealthWithResponse request returning *GetHealthResponse
+func (c *ClientWithResponses) GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error) {
+	rsp, err := c.GetHealth(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetHealthResponse(rsp)
+}
+
+// GetHealthLiveWithResponse request returning *GetHealthLiveResponse
+func (c *ClientWithResponses) GetHealthLiveWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthLiveResponse, error) {
+	rsp, err := c.GetHealthLive(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetHealthLiveResponse(rsp)
+}
+
+// GetHealthReadyWithResponse request returning *GetHealthReadyResponse
+func (c *ClientWithResponses) GetHealthReadyWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthReadyResponse, error) {
+	rsp, err := c.GetHealthReady(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetHealthReadyResponse(rsp)
+}
+
+// GetUnsubscribeTokenWithResponse request returning *GetUnsubscribeTokenResponse
+func (c *ClientWithResponses) GetUnsubscribeTokenWithResponse(ctx context.Context, token string, reqEditors ...RequestEditorFn) (*GetUnsubscribeTokenResponse, error) {
+	rsp, err := c.GetUnsubscribeToken(ctx, token, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetUnsubscribeTokenResponse(rsp)
+}
+
+// PostUnsubscribeTokenWithResponse request returning *PostUnsubscribeTokenResponse
+func (c *ClientWithResponses) PostUnsubscribeTokenWithResponse(ctx context.Context, token string, reqEditors ...RequestEditorFn) (*PostUnsubscribeTokenResponse, error) {
+	rsp, err := c.PostUnsubscribeToken(ctx, token, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostUnsubscribeTokenResponse(rsp)
+}
+
+// PostV3EmailWithBodyWithResponse request with arbitrary body returning *PostV3EmailResponse
+func (c *ClientWithResponses) PostV3EmailWithBodyWithResponse(ctx context.Context, params *PostV3EmailParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostV3EmailResponse, error) {
+	rsp, err := c.PostV3EmailWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostV3EmailResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostV3EmailWithResponse(ctx context.Context, params *PostV3EmailParams, body PostV3EmailJSONRequestBody, reqEditors ...RequestEditorFn) (*PostV3EmailResponse, error) {
+	rsp, err := c.PostV3Email(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostV3EmailResponse(rsp)
+}
+
+// DeleteV3MessagesMessageIdWithResponse request returning *DeleteV3MessagesMessageIdResponse
+func (c *ClientWithResponses) DeleteV3MessagesMessageIdWithResponse(ctx context.Context, messageId string, params *DeleteV3MessagesMessageIdParams, reqEditors ...RequestEditorFn) (*DeleteV3MessagesMessageIdResponse, error) {
+	rsp, err := c.DeleteV3MessagesMessageId(ctx, messageId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteV3MessagesMessageIdResponse(rsp)
+}
+
+// GetV3MessagesMessageIdWithResponse request returning *GetV3MessagesMessageIdResponse
+func (c *ClientWithResponses) GetV3MessagesMessageIdWithResponse(ctx context.Context, messageId string, params *GetV3MessagesMessageIdParams, reqEditors ...RequestEditorFn) (*GetV3MessagesMessageIdResponse, error) {
+	rsp, err := c.GetV3MessagesMessageId(ctx, messageId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetV3MessagesMessageIdResponse(rsp)
+}
+
+// PostV3SmsWithBodyWithResponse request with arbitrary body returning *PostV3SmsResponse
+func (c *ClientWithResponses) PostV3SmsWithBodyWithResponse(ctx context.Context, params *PostV3SmsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostV3SmsResponse, error) {
+	rsp, err := c.PostV3SmsWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostV3SmsResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostV3SmsWithResponse(ctx context.Context, params *PostV3SmsParams, body PostV3SmsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostV3SmsResponse, error) {
+	rsp, err := c.PostV3Sms(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostV3SmsResponse(rsp)
+}
+
+// GetV3SuppressionsWithResponse request returning *GetV3SuppressionsResponse
+func (c *ClientWithResponses) GetV3SuppressionsWithResponse(ctx context.Context, params *GetV3SuppressionsParams, reqEditors ...RequestEditorFn) (*GetV3SuppressionsResponse, error) {
+	rsp, err := c.GetV3Suppressions(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetV3SuppressionsResponse(rsp)
+}
+
+// PostV3SuppressionsWithBodyWithResponse request with arbitrary body returning *PostV3SuppressionsResponse
+func (c *ClientWithResponses) PostV3SuppressionsWithBodyWithResponse(ctx context.Context, params *PostV3SuppressionsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostV3SuppressionsResponse, error) {
+	rsp, err := c.PostV3SuppressionsWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostV3SuppressionsResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostV3SuppressionsWithResponse(ctx context.Context, params *PostV3SuppressionsParams, body PostV3SuppressionsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostV3SuppressionsResponse, error) {
+	rsp, err := c.PostV3Suppressions(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostV3SuppressionsResponse(rsp)
+}
+
+// DeleteV3SuppressionsRecipientWithResponse request returning *DeleteV3SuppressionsRecipientResponse
+func (c *ClientWithResponses) DeleteV3SuppressionsRecipientWithResponse(ctx context.Context, recipient string, params *DeleteV3SuppressionsRecipientParams, reqEditors ...RequestEditorFn) (*DeleteV3SuppressionsRecipientResponse, error) {
+	rsp, err := c.DeleteV3SuppressionsRecipient(ctx, recipient, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteV3SuppressionsRecipientResponse(rsp)
+}
+
+// PostWebhooksBouncesWithBodyWithResponse request with arbitrary body returning *PostWebhooksBouncesResponse
+func (c *ClientWithResponses) PostWebhooksBouncesWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostWebhooksBouncesResponse, error) {
+	rsp, err := c.PostWebhooksBouncesWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostWebhooksBouncesResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostWebhooksBouncesWithResponse(ctx context.Context, body PostWebhooksBouncesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostWebhooksBouncesResponse, error) {
+	rsp, err := c.PostWebhooksBounces(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostWebhooksBouncesResponse(rsp)
+}
+
+// ParseGetAdminConfigResponse parses an HTTP response from a GetAdminConfigWithResponse call
+func ParseGetAdminConfigResponse(rsp *http.Response) (*GetAdminConfigResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAdminConfigResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ConfigStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetHealthResponse parses an HTTP response from a GetHealthWithResponse call
+func ParseGetHealthResponse(rsp *http.Response) (*GetHealthResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetHealthResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest LivenessStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetHealthLiveResponse parses an HTTP response from a GetHealthLiveWithResponse call
+func ParseGetHealthLiveResponse(rsp *http.Response) (*GetHealthLiveResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetHealthLiveResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest LivenessStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetHealthReadyResponse parses an HTTP response from a GetHealthReadyWithResponse call
+func ParseGetHealthReadyResponse(rsp *http.Response) (*GetHealthReadyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetHealthReadyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReadinessReport
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest ReadinessReport
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetUnsubscribeTokenResponse parses an HTTP response from a GetUnsubscribeTokenWithResponse call
+func ParseGetUnsubscribeTokenResponse(rsp *http.Response) (*GetUnsubscribeTokenResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetUnsubscribeTokenResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostUnsubscribeTokenResponse parses an HTTP response from a PostUnsubscribeTokenWithResponse call
+func ParsePostUnsubscribeTokenResponse(rsp *http.Response) (*PostUnsubscribeTokenResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostUnsubscribeTokenResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostV3EmailResponse parses an HTTP response from a PostV3EmailWithResponse call
+func ParsePostV3EmailResponse(rsp *http.Response) (*PostV3EmailResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostV3EmailResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest EmailSuccessResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 413:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON413 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteV3MessagesMessageIdResponse parses an HTTP response from a DeleteV3MessagesMessageIdWithResponse call
+func ParseDeleteV3MessagesMessageIdResponse(rsp *http.Response) (*DeleteV3MessagesMessageIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteV3MessagesMessageIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SuccessResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
 		}
-	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
 		}
+		response.JSON409 = &dest
+
 	}
-	return nil
-}
 
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
+	return response, nil
 }
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
+// ParseGetV3MessagesMessageIdResponse parses an HTTP response from a GetV3MessagesMessageIdWithResponse call
+func ParseGetV3MessagesMessageIdResponse(rsp *http.Response) (*GetV3MessagesMessageIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return &ClientWithResponses{client}, nil
-}
 
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
-			return err
-		}
-		c.Server = newBaseURL.String()
-		return nil
+	response := &GetV3MessagesMessageIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-}
 
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// GetHealthWithResponse request
-	GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error)
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest MessageStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
 
-	// PostV3EmailWithBodyWithResponse request with any body
-	PostV3EmailWithBodyWithResponse(ctx context.Context, params *PostV3EmailParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostV3EmailResponse, error)
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
 
-	PostV3EmailWithResponse(ctx context.Context, params *PostV3EmailParams, body PostV3EmailJSONRequestBody, reqEditors ...RequestEditorFn) (*PostV3EmailResponse, error)
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
 
-	// PostV3SmsWithBodyWithResponse request with any body
-	PostV3SmsWithBodyWithResponse(ctx context.Context, params *PostV3SmsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostV3SmsResponse, error)
+	}
 
-	PostV3SmsWithResponse(ctx context.Context, params *PostV3SmsParams, body PostV3SmsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostV3SmsResponse, error)
+	return response, nil
 }
 
-type GetHealthResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *struct {
-		Status    *string    `json:"status,omitempty"`
-		Timestamp *time.Time `json:"timestamp,omitempty"`
+// ParsePostV3SmsResponse parses an HTTP response from a PostV3SmsWithResponse call
+func ParsePostV3SmsResponse(rsp *http.Response) (*PostV3SmsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	JSON503 *ErrorResponse
-}
 
-// Status returns HTTPResponse.Status
-func (r GetHealthResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	response := &PostV3SmsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetHealthResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
-	}
-	return 0
-}
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest SmsSuccessResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
 
-type PostV3EmailResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON202      *SuccessResponse
-	JSON400      *ErrorResponse
-	JSON401      *ErrorResponse
-}
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
 
-// Status returns HTTPResponse.Status
-func (r PostV3EmailResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
-}
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PostV3EmailResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
-	}
-	return 0
-}
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 413:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON413 = &dest
 
-type PostV3SmsResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON202      *SmsSuccessResponse
-	JSON400      *ErrorResponse
-	JSON401      *ErrorResponse
-}
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
 
-// Status returns HTTPResponse.Status
-func (r PostV3SmsResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PostV3SmsResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
-	}
-	return 0
+	return response, nil
 }
 
-// GetHealthWithResponse request returning *GetHealthResponse
-func (c *ClientWithResponses) GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error) {
-	rsp, err := c.GetHealth(ctx, reqEditors...)
+// ParseGetV3SuppressionsResponse parses an HTTP response from a GetV3SuppressionsWithResponse call
+func ParseGetV3SuppressionsResponse(rsp *http.Response) (*GetV3SuppressionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetHealthResponse(rsp)
-}
 
-// PostV3EmailWithBodyWithResponse request with arbitrary body returning *PostV3EmailResponse
-func (c *ClientWithResponses) PostV3EmailWithBodyWithResponse(ctx context.Context, params *PostV3EmailParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostV3EmailResponse, error) {
-	rsp, err := c.PostV3EmailWithBody(ctx, params, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &GetV3SuppressionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParsePostV3EmailResponse(rsp)
-}
 
-func (c *ClientWithResponses) PostV3EmailWithResponse(ctx context.Context, params *PostV3EmailParams, body PostV3EmailJSONRequestBody, reqEditors ...RequestEditorFn) (*PostV3EmailResponse, error) {
-	rsp, err := c.PostV3Email(ctx, params, body, reqEditors...)
-	if err != nil {
-		return nil, err
-	}
-	return ParsePostV3EmailResponse(rsp)
-}
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SuppressionList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
 
-// PostV3SmsWithBodyWithResponse request with arbitrary body returning *PostV3SmsResponse
-func (c *ClientWithResponses) PostV3SmsWithBodyWithResponse(ctx context.Context, params *PostV3SmsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostV3SmsResponse, error) {
-	rsp, err := c.PostV3SmsWithBody(ctx, params, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
-	}
-	return ParsePostV3SmsResponse(rsp)
-}
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
 
-func (c *ClientWithResponses) PostV3SmsWithResponse(ctx context.Context, params *PostV3SmsParams, body PostV3SmsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostV3SmsResponse, error) {
-	rsp, err := c.PostV3Sms(ctx, params, body, reqEditors...)
-	if err != nil {
-		return nil, err
 	}
-	return ParsePostV3SmsResponse(rsp)
+
+	return response, nil
 }
 
-// ParseGetHealthResponse parses an HTTP response from a GetHealthWithResponse call
-func ParseGetHealthResponse(rsp *http.Response) (*GetHealthResponse, error) {
+// ParsePostV3SuppressionsResponse parses an HTTP response from a PostV3SuppressionsWithResponse call
+func ParsePostV3SuppressionsResponse(rsp *http.Response) (*PostV3SuppressionsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetHealthResponse{
+	response := &PostV3SuppressionsResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest struct {
-			Status    *string    `json:"status,omitempty"`
-			Timestamp *time.Time `json:"timestamp,omitempty"`
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest SuppressionEntry
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
 		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON200 = &dest
+		response.JSON400 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
 		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON503 = &dest
+		response.JSON401 = &dest
 
 	}
 
 	return response, nil
 }
 
-// ParsePostV3EmailResponse parses an HTTP response from a PostV3EmailWithResponse call
-func ParsePostV3EmailResponse(rsp *http.Response) (*PostV3EmailResponse, error) {
+// ParseDeleteV3SuppressionsRecipientResponse parses an HTTP response from a DeleteV3SuppressionsRecipientWithResponse call
+func ParseDeleteV3SuppressionsRecipientResponse(rsp *http.Response) (*DeleteV3SuppressionsRecipientResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &PostV3EmailResponse{
+	response := &DeleteV3SuppressionsRecipientResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
 		var dest SuccessResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON202 = &dest
+		response.JSON200 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
 		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON400 = &dest
+		response.JSON401 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
 		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON401 = &dest
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
 
 	}
 
 	return response, nil
 }
 
-// ParsePostV3SmsResponse parses an HTTP response from a PostV3SmsWithResponse call
-func ParsePostV3SmsResponse(rsp *http.Response) (*PostV3SmsResponse, error) {
+// ParsePostWebhooksBouncesResponse parses an HTTP response from a PostWebhooksBouncesWithResponse call
+func ParsePostWebhooksBouncesResponse(rsp *http.Response) (*PostWebhooksBouncesResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &PostV3SmsResponse{
+	response := &PostWebhooksBouncesResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
-		var dest SmsSuccessResponse
+		var dest SuccessResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1165,6 +3089,13 @@ func ParsePostV3SmsResponse(rsp *http.Response) (*PostV3SmsResponse, error) {
 		}
 		response.JSON401 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
 	}
 
 	return response, nil
@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/Low-Stack-Technologies/message-delivery-service/clients/go/api"
+	"go.opentelemetry.io/otel/propagation"
 	"golang.org/x/crypto/ssh"
 )
 
@@ -91,7 +92,10 @@ func NewClient(serverURL, clientID, privKeyStr string) (*Client, error) {
 		privKey:  privKey,
 	}
 
-	apiClient, err := api.NewClientWithResponses(serverURL, api.WithRequestEditorFn(c.signerInterceptor))
+	apiClient, err := api.NewClientWithResponses(serverURL,
+		api.WithRequestEditorFn(traceInterceptor),
+		api.WithRequestEditorFn(c.signerInterceptor),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create API client: %w", err)
 	}
@@ -100,6 +104,16 @@ func NewClient(serverURL, clientID, privKeyStr string) (*Client, error) {
 	return c, nil
 }
 
+var tracePropagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+// traceInterceptor is an oapi-codegen RequestEditorFn that propagates the
+// OpenTelemetry span in ctx as traceparent/tracestate (and baggage) headers,
+// so the service's spans join the caller's trace.
+func traceInterceptor(ctx context.Context, req *http.Request) error {
+	tracePropagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+	return nil
+}
+
 // signerInterceptor is an oapi-codegen RequestEditorFn that automatically signs requests.
 func (c *Client) signerInterceptor(ctx context.Context, req *http.Request) error {
 	timestamp := time.Now().Format(time.RFC3339)
@@ -135,7 +149,7 @@ func (c *Client) signerInterceptor(ctx context.Context, req *http.Request) error
 }
 
 // SendEmail sends an email request to the service.
-func (c *Client) SendEmail(ctx context.Context, emailReq api.EmailRequest) (*api.SuccessResponse, error) {
+func (c *Client) SendEmail(ctx context.Context, emailReq api.EmailRequest) (*api.EmailSuccessResponse, error) {
 	resp, err := c.apiClient.PostV3EmailWithResponse(ctx, &api.PostV3EmailParams{
 		XClientId:  c.clientID,
 		XTimestamp: time.Now(),
@@ -150,12 +164,12 @@ func (c *Client) SendEmail(ctx context.Context, emailReq api.EmailRequest) (*api
 
 	// Fallback for 202 Accepted if JSON202 is nil (e.g. Content-Type mismatch)
 	if resp.StatusCode() == http.StatusAccepted {
-		var successResp api.SuccessResponse
+		var successResp api.EmailSuccessResponse
 		if err := json.Unmarshal(resp.Body, &successResp); err == nil {
 			return &successResp, nil
 		}
 		// If unmarshal fails but it's 202, still consider it success
-		return &api.SuccessResponse{Success: true, Message: "Accepted (raw)"}, nil
+		return &api.EmailSuccessResponse{Success: true, Message: "Accepted (raw)"}, nil
 	}
 
 	// Try parsing as ErrorResponse
@@ -199,3 +213,95 @@ func (c *Client) SendSms(ctx context.Context, smsReq api.SmsRequest) (*api.SmsSu
 
 	return nil, fmt.Errorf("API error: %s", resp.Status())
 }
+
+// GetMessageStatus returns the delivery status of a sent email or a
+// scheduled message, by the message ID returned by SendEmail or SendSms.
+func (c *Client) GetMessageStatus(ctx context.Context, messageID string) (*api.MessageStatus, error) {
+	resp, err := c.apiClient.GetV3MessagesMessageIdWithResponse(ctx, messageID, &api.GetV3MessagesMessageIdParams{
+		XClientId:  c.clientID,
+		XTimestamp: time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.JSON200 != nil {
+		return resp.JSON200, nil
+	}
+	return nil, responseError(resp.Body, resp.Status())
+}
+
+// CancelMessage cancels a message scheduled with SendAt that has not been
+// sent yet, by the message ID returned by SendEmail or SendSms.
+func (c *Client) CancelMessage(ctx context.Context, messageID string) error {
+	resp, err := c.apiClient.DeleteV3MessagesMessageIdWithResponse(ctx, messageID, &api.DeleteV3MessagesMessageIdParams{
+		XClientId:  c.clientID,
+		XTimestamp: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() == http.StatusOK {
+		return nil
+	}
+	return responseError(resp.Body, resp.Status())
+}
+
+// ListSuppressions lists the suppressed recipients in scope, or in every scope
+// when scope is empty.
+func (c *Client) ListSuppressions(ctx context.Context, scope string) (*api.SuppressionList, error) {
+	params := &api.GetV3SuppressionsParams{XClientId: c.clientID, XTimestamp: time.Now()}
+	if scope != "" {
+		params.Scope = &scope
+	}
+	resp, err := c.apiClient.GetV3SuppressionsWithResponse(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if resp.JSON200 != nil {
+		return resp.JSON200, nil
+	}
+	return nil, responseError(resp.Body, resp.Status())
+}
+
+// AddSuppression stops sends to a recipient, in every service.
+func (c *Client) AddSuppression(ctx context.Context, req api.SuppressionRequest) (*api.SuppressionEntry, error) {
+	resp, err := c.apiClient.PostV3SuppressionsWithResponse(ctx, &api.PostV3SuppressionsParams{
+		XClientId:  c.clientID,
+		XTimestamp: time.Now(),
+	}, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.JSON201 != nil {
+		return resp.JSON201, nil
+	}
+	return nil, responseError(resp.Body, resp.Status())
+}
+
+// RemoveSuppression lifts the suppression of a recipient in scope (default
+// "global").
+func (c *Client) RemoveSuppression(ctx context.Context, recipient, scope string) error {
+	params := &api.DeleteV3SuppressionsRecipientParams{XClientId: c.clientID, XTimestamp: time.Now()}
+	if scope != "" {
+		params.Scope = &scope
+	}
+	resp, err := c.apiClient.DeleteV3SuppressionsRecipientWithResponse(ctx, recipient, params)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() == http.StatusOK {
+		return nil
+	}
+	return responseError(resp.Body, resp.Status())
+}
+
+// responseError describes a failed response, using its ErrorResponse code
+// when it has one.
+func responseError(body []byte, status string) error {
+	var errResp api.ErrorResponse
+	if err := json.Unmarshal(body, &errResp); err == nil && !errResp.Success && errResp.Error.Code != "" {
+		return fmt.Errorf("API error (%s): %s", errResp.Error.Code, errResp.Error.Message)
+	}
+	return fmt.Errorf("API error: %s", status)
+}
@@ -2,11 +2,21 @@ module github.com/Low-Stack-Technologies/message-delivery-service/clients/go
 
 go 1.25.5
 
+require (
+	github.com/oapi-codegen/runtime v1.1.2
+	go.opentelemetry.io/otel v1.46.0
+	golang.org/x/crypto v0.46.0
+)
+
 require (
 	github.com/apapsch/go-jsonmerge/v2 v2.0.0 // indirect
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
 	github.com/go-chi/chi/v5 v5.2.3 // indirect
+	github.com/go-logr/logr v1.4.4 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
 	github.com/google/uuid v1.5.0 // indirect
-	github.com/oapi-codegen/runtime v1.1.2 // indirect
-	golang.org/x/crypto v0.46.0 // indirect
+	go.opentelemetry.io/auto/sdk v1.2.1 // indirect
+	go.opentelemetry.io/otel/metric v1.46.0 // indirect
+	go.opentelemetry.io/otel/trace v1.46.0 // indirect
 	golang.org/x/sys v0.39.0 // indirect
 )
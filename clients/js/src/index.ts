@@ -3,8 +3,13 @@ import { createHash } from "crypto";
 import type {
   EmailRequest,
   SmsRequest,
-  SuccessResponse,
+  EmailSuccessResponse,
   SmsSuccessResponse,
+  MessageStatus,
+  SuppressionRequest,
+  SuppressionEntry,
+  SuppressionList,
+  SuccessResponse,
   ErrorResponse,
 } from "./types.js";
 
@@ -177,7 +182,8 @@ export class MdsClient {
     body?: unknown
   ): Promise<T> {
     const bodyStr = body ? JSON.stringify(body) : "";
-    const { timestamp, signature } = await this.signRequest(method, path, bodyStr);
+    // Only the path is signed, not the query string
+    const { timestamp, signature } = await this.signRequest(method, path.split("?")[0], bodyStr);
 
     const response = await fetch(`${this.serverUrl}${path}`, {
       method,
@@ -201,7 +207,7 @@ export class MdsClient {
       throw new Error(`API error: ${response.status} ${response.statusText}`);
     }
 
-    if (response.status === 202 || response.status === 200) {
+    if (response.status === 202 || response.status === 200 || response.status === 201) {
       return parsed as T;
     }
 
@@ -216,8 +222,8 @@ export class MdsClient {
   /**
    * Sends an email through the Message Delivery Service.
    */
-  async sendEmail(request: EmailRequest): Promise<SuccessResponse> {
-    return this.request<SuccessResponse>("POST", "/v3/email", request);
+  async sendEmail(request: EmailRequest): Promise<EmailSuccessResponse> {
+    return this.request<EmailSuccessResponse>("POST", "/v3/email", request);
   }
 
   /**
@@ -227,6 +233,43 @@ export class MdsClient {
     return this.request<SmsSuccessResponse>("POST", "/v3/sms", request);
   }
 
+  /**
+   * Returns the delivery status of a sent email or a scheduled message, by the messageId returned by sendEmail or sendSms.
+   */
+  async getMessageStatus(messageId: string): Promise<MessageStatus> {
+    return this.request<MessageStatus>("GET", `/v3/messages/${messageId}`);
+  }
+
+  /**
+   * Cancels a message scheduled with `sendAt` that has not been sent yet.
+   */
+  async cancelMessage(messageId: string): Promise<SuccessResponse> {
+    return this.request<SuccessResponse>("DELETE", `/v3/messages/${messageId}`);
+  }
+
+  /**
+   * Lists suppressed recipients in a scope, or in every scope when omitted.
+   */
+  async listSuppressions(scope?: string): Promise<SuppressionList> {
+    const query = scope ? `?scope=${encodeURIComponent(scope)}` : "";
+    return this.request<SuppressionList>("GET", `/v3/suppressions${query}`);
+  }
+
+  /**
+   * Stops sends to a recipient, in every service.
+   */
+  async addSuppression(request: SuppressionRequest): Promise<SuppressionEntry> {
+    return this.request<SuppressionEntry>("POST", "/v3/suppressions", request);
+  }
+
+  /**
+   * Lifts the suppression of a recipient in a scope (default "global").
+   */
+  async removeSuppression(recipient: string, scope?: string): Promise<SuccessResponse> {
+    const query = scope ? `?scope=${encodeURIComponent(scope)}` : "";
+    return this.request<SuccessResponse>("DELETE", `/v3/suppressions/${recipient}${query}`);
+  }
+
   /**
    * Checks the health of the Message Delivery Service.
    */
@@ -10,7 +10,13 @@ type Schemas = components["schemas"];
 export type EmailContact = Schemas["EmailContact"];
 export type EmailRequest = Schemas["EmailRequest"];
 export type SuccessResponse = Schemas["SuccessResponse"];
+export type EmailSuccessResponse = Schemas["EmailSuccessResponse"];
 export type ErrorResponse = Schemas["ErrorResponse"];
 export type SmsRecipient = Schemas["SmsRecipient"];
 export type SmsRequest = Schemas["SmsRequest"];
 export type SmsSuccessResponse = Schemas["SmsSuccessResponse"];
+export type MessageStatus = Schemas["MessageStatus"];
+export type RecipientStatus = Schemas["RecipientStatus"];
+export type SuppressionRequest = Schemas["SuppressionRequest"];
+export type SuppressionEntry = Schemas["SuppressionEntry"];
+export type SuppressionList = Schemas["SuppressionList"];
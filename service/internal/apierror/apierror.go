@@ -0,0 +1,124 @@
+// Package apierror writes the ErrorResponse body documented in openapi.yaml so
+// that every failure path, from authentication to delivery, returns the same
+// machine-readable shape.
+package apierror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/pkg/api"
+)
+
+// Stable error codes returned in ErrorResponse.error.code. Clients may switch
+// on these values, so existing codes must never be renamed.
+const (
+	// Authentication
+	CodeMissingAuthHeaders       = "MISSING_AUTH_HEADERS"
+	CodeInvalidTimestamp         = "INVALID_TIMESTAMP"
+	CodeTimestampExpired         = "TIMESTAMP_EXPIRED"
+	CodeUnknownClient            = "UNKNOWN_CLIENT"
+	CodeInvalidAuthHeader        = "INVALID_AUTH_HEADER"
+	CodeInvalidSignatureEncoding = "INVALID_SIGNATURE_ENCODING"
+	CodeSignatureInvalid         = "SIGNATURE_INVALID"
+
+	// Request validation
+	CodeInvalidBody      = "INVALID_BODY"
+	CodePayloadTooLarge  = "PAYLOAD_TOO_LARGE"
+	CodeInvalidParameter = "INVALID_PARAMETER"
+	CodeNoRecipients     = "NO_RECIPIENTS"
+
+	// Authorization
+	CodeForbidden = "FORBIDDEN"
+
+	// Routing
+	CodeNotFound         = "NOT_FOUND"
+	CodeMethodNotAllowed = "METHOD_NOT_ALLOWED"
+
+	// Delivery
+	CodeDeliveryFailed = "DELIVERY_FAILED"
+	CodeNotScheduled   = "NOT_SCHEDULED"
+
+	// Server
+	CodeInternal = "INTERNAL_ERROR"
+)
+
+// Write sends an ErrorResponse with the given HTTP status, error code and
+// human-readable message. Optional details carry field-level information.
+func Write(w http.ResponseWriter, status int, code, message string, details ...string) {
+	var resp api.ErrorResponse
+	resp.Success = false
+	resp.Error.Code = code
+	resp.Error.Message = message
+	if len(details) > 0 {
+		resp.Error.Details = &details
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// DecodeDetails describes a JSON decoding error in terms of the offending
+// field or position so that callers can fix their payload.
+func DecodeDetails(err error) []string {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+
+	switch {
+	case errors.As(err, &syntaxErr):
+		return []string{fmt.Sprintf("malformed JSON at offset %d", syntaxErr.Offset)}
+	case errors.As(err, &typeErr):
+		field := typeErr.Field
+		if field == "" {
+			field = "(root)"
+		}
+		return []string{fmt.Sprintf("%s: expected %s, got %s", field, typeErr.Type, typeErr.Value)}
+	case errors.Is(err, io.EOF):
+		return []string{"request body is empty"}
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return []string{"request body is truncated"}
+	}
+
+	// Union types generated for oneOf schemas report their own messages.
+	msg := strings.TrimPrefix(err.Error(), "json: ")
+	return []string{msg}
+}
+
+// ParamErrorHandler reports header and parameter errors raised by the
+// generated server wrapper. It is meant for api.ChiServerOptions.
+func ParamErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	var requiredHeader *api.RequiredHeaderError
+	var invalidFormat *api.InvalidParamFormatError
+	var tooMany *api.TooManyValuesForParamError
+
+	switch {
+	case errors.As(err, &requiredHeader):
+		Write(w, http.StatusBadRequest, CodeInvalidParameter, "Missing required header",
+			fmt.Sprintf("%s: header is required", requiredHeader.ParamName))
+	case errors.As(err, &invalidFormat):
+		Write(w, http.StatusBadRequest, CodeInvalidParameter, "Invalid parameter format",
+			fmt.Sprintf("%s: %v", invalidFormat.ParamName, invalidFormat.Err))
+	case errors.As(err, &tooMany):
+		Write(w, http.StatusBadRequest, CodeInvalidParameter, "Parameter specified more than once",
+			fmt.Sprintf("%s: expected one value, got %d", tooMany.ParamName, tooMany.Count))
+	default:
+		Write(w, http.StatusBadRequest, CodeInvalidParameter, err.Error())
+	}
+}
+
+// NotFound is a router fallback for unknown routes.
+func NotFound(w http.ResponseWriter, r *http.Request) {
+	Write(w, http.StatusNotFound, CodeNotFound, "Route not found",
+		fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+}
+
+// MethodNotAllowed is a router fallback for known routes with the wrong verb.
+func MethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	Write(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "Method not allowed",
+		fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+}
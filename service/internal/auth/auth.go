@@ -4,150 +4,204 @@ import (
 	"bytes"
 	"crypto/ed25519"
 	"crypto/sha256"
-	"crypto/x509"
 	"encoding/base64"
 	"encoding/hex"
-	"encoding/pem"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/apierror"
 	"github.com/Low-Stack-Technologies/message-delivery-service/internal/config"
-	"golang.org/x/crypto/ssh"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/metrics"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
-func NewMiddleware() func(http.Handler) http.Handler {
+// ClientIDAttr is the span attribute carrying the authenticated service ID.
+const ClientIDAttr = "mds.client_id"
+
+// NewMiddleware authenticates requests against the services in the store's
+// active config. Rejections are counted in m by error code. Authentication
+// runs in its own span; the handler's spans are its siblings.
+func NewMiddleware(store *config.Store, logger *slog.Logger, m *metrics.Metrics) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			clientID := r.Header.Get("X-Client-Id")
 			timestampStr := r.Header.Get("X-Timestamp")
 			authHeader := r.Header.Get("Authorization")
 
-			config.DebugLog("[DEBUG] Auth Attempt - ClientID: %s, Timestamp: %s, Auth: %s", clientID, timestampStr, authHeader)
-
-			if r.URL.Path == "/health" {
+			if isPublic(r.URL.Path) {
 				next.ServeHTTP(w, r)
 				return
 			}
 
+			ctx, span := tracing.Start(r.Context(), "auth")
+			defer span.End()
+			reject := func(w http.ResponseWriter, status int, code, message string, details ...string) {
+				m.AuthFailure(code)
+				span.SetStatus(codes.Error, code)
+				apierror.Write(w, status, code, message, details...)
+			}
+			accept := func(service *config.Service, method string) {
+				logger.DebugContext(ctx, "Auth succeeded", "client_id", service.ID, "method", method)
+				metrics.SetClientID(ctx, service.ID)
+				span.SetAttributes(attribute.String(ClientIDAttr, service.ID), attribute.String("mds.auth.method", method))
+				span.End()
+				trace.SpanFromContext(r.Context()).SetAttributes(attribute.String(ClientIDAttr, service.ID))
+				next.ServeHTTP(w, r.WithContext(withService(r.Context(), service)))
+			}
+
+			logger.DebugContext(ctx, "Auth attempt", "client_id", clientID, "timestamp", timestampStr, "authorization", authHeader)
+
+			cfg := store.Get()
+
+			// 0. Client Certificate (mTLS) as an alternative to signing
+			if service, ok := certService(cfg, r); ok {
+				if clientID != "" && clientID != service.ID {
+					logger.DebugContext(ctx, "Auth failed: client ID does not match certificate", "client_id", clientID, "service", service.ID)
+					reject(w, http.StatusUnauthorized, apierror.CodeUnknownClient, "Client ID does not match client certificate",
+						"X-Client-Id: must match the service mapped to the client certificate")
+					return
+				}
+				if _, ok := readBody(logger, w, r, cfg.MaxBodyBytes(r.URL.Path)); !ok {
+					return
+				}
+				accept(service, "client_certificate")
+				return
+			}
+
 			if clientID == "" || timestampStr == "" || authHeader == "" {
-				config.DebugLog("[DEBUG] Auth Failed - Missing headers")
-				http.Error(w, "Missing authentication headers", http.StatusUnauthorized)
+				logger.DebugContext(ctx, "Auth failed: missing headers")
+				var missing []string
+				for _, name := range []string{"X-Client-Id", "X-Timestamp", "Authorization"} {
+					if r.Header.Get(name) == "" {
+						missing = append(missing, name+": header is required")
+					}
+				}
+				reject(w, http.StatusUnauthorized, apierror.CodeMissingAuthHeaders, "Missing authentication headers", missing...)
 				return
 			}
 
 			// 1. Verify Timestamp (Replay Protection)
 			timestamp, err := time.Parse(time.RFC3339, timestampStr)
 			if err != nil {
-				config.DebugLog("[DEBUG] Auth Failed - Invalid timestamp format: %v", err)
-				http.Error(w, "Invalid timestamp format", http.StatusBadRequest)
+				logger.DebugContext(ctx, "Auth failed: invalid timestamp", "error", err)
+				reject(w, http.StatusBadRequest, apierror.CodeInvalidTimestamp, "Invalid timestamp format",
+					"X-Timestamp: expected RFC 3339 date-time")
 				return
 			}
 			if time.Since(timestamp) > 5*time.Minute || time.Since(timestamp) < -5*time.Minute {
-				config.DebugLog("[DEBUG] Auth Failed - Timestamp expired: diff=%v", time.Since(timestamp))
-				http.Error(w, "Request timestamp expired or in the future", http.StatusUnauthorized)
+				logger.DebugContext(ctx, "Auth failed: timestamp expired", "skew", time.Since(timestamp))
+				reject(w, http.StatusUnauthorized, apierror.CodeTimestampExpired, "Request timestamp expired or in the future",
+					"X-Timestamp: must be within 5 minutes of server time")
 				return
 			}
 
 			// 2. Find Service & Public Key
-			cfg := config.Get()
-			var service *config.ServiceConfig
-			for _, s := range cfg.Services {
-				if s.ID == clientID {
-					service = &s
-					break
-				}
-			}
-
-			if service == nil {
-				config.DebugLog("[DEBUG] Auth Failed - Unknown Client ID: %s", clientID)
-				http.Error(w, "Unknown Client ID", http.StatusUnauthorized)
+			service, ok := cfg.Registry().Lookup(clientID)
+			if !ok {
+				logger.DebugContext(ctx, "Auth failed: unknown client", "client_id", clientID)
+				reject(w, http.StatusUnauthorized, apierror.CodeUnknownClient, "Unknown Client ID",
+					"X-Client-Id: no service registered with this ID")
 				return
 			}
-
-			pubKey, err := parsePublicKey(service.PublicKey)
-			if err != nil {
-				config.DebugLog("[DEBUG] Auth Failed - Public key parsing error: %v", err)
-				http.Error(w, "Service public key is misconfigured", http.StatusInternalServerError)
+			if service.Key == nil {
+				logger.DebugContext(ctx, "Auth failed: service has no public key", "client_id", clientID)
+				reject(w, http.StatusUnauthorized, apierror.CodeSignatureInvalid, "Service does not accept request signatures",
+					"X-Client-Id: service authenticates with client certificates only")
 				return
 			}
 
 			// 3. Construct Canonical Request
-			bodyBytes, _ := io.ReadAll(r.Body)
-			r.Body = io.NopCloser(bytes.NewBuffer(bodyBytes)) // Restore body for later handlers
-
-			bodyHash := sha256.Sum256(bodyBytes)
-			bodyHashHex := hex.EncodeToString(bodyHash[:])
+			bodyHashHex, ok := readBody(logger, w, r, cfg.MaxBodyBytes(r.URL.Path))
+			if !ok {
+				return
+			}
 
 			// Canonical = Method + "\n" + Path + "\n" + X-Timestamp + "\n" + SHA256(Body)
 			canonical := r.Method + "\n" + r.URL.Path + "\n" + timestampStr + "\n" + bodyHashHex
-			config.DebugLog("[DEBUG] Canonical Request:\n%s", canonical)
+			logger.DebugContext(ctx, "Canonical request", "method", r.Method, "path", r.URL.Path, "timestamp", timestampStr, "body_sha256", bodyHashHex)
 
 			// 4. Verify Signature
 			if len(authHeader) < 10 || authHeader[:10] != "Signature " {
-				config.DebugLog("[DEBUG] Auth Failed - Invalid Auth header format")
-				http.Error(w, "Invalid Authorization header format", http.StatusUnauthorized)
+				logger.DebugContext(ctx, "Auth failed: invalid Authorization header")
+				reject(w, http.StatusUnauthorized, apierror.CodeInvalidAuthHeader, "Invalid Authorization header format",
+					"Authorization: expected \"Signature <base64_signature>\"")
 				return
 			}
 			signatureB64 := authHeader[10:]
 			signature, err := base64.StdEncoding.DecodeString(signatureB64)
 			if err != nil {
-				config.DebugLog("[DEBUG] Auth Failed - Signature decode error: %v", err)
-				http.Error(w, "Invalid signature encoding", http.StatusUnauthorized)
+				logger.DebugContext(ctx, "Auth failed: signature is not Base64", "error", err)
+				reject(w, http.StatusUnauthorized, apierror.CodeInvalidSignatureEncoding, "Invalid signature encoding",
+					"Authorization: signature must be standard Base64")
 				return
 			}
 
-			if !ed25519.Verify(pubKey, []byte(canonical), signature) {
-				config.DebugLog("[DEBUG] Auth Failed - Ed25519 verification failed")
-				http.Error(w, "Signature verification failed", http.StatusUnauthorized)
+			if !ed25519.Verify(service.Key, []byte(canonical), signature) {
+				logger.DebugContext(ctx, "Auth failed: signature verification failed", "client_id", clientID)
+				reject(w, http.StatusUnauthorized, apierror.CodeSignatureInvalid, "Signature verification failed",
+					"Check your canonical request construction")
 				return
 			}
 
-			config.DebugLog("[DEBUG] Auth Success - ClientID: %s", clientID)
-			next.ServeHTTP(w, r)
+			accept(service, "signature")
 		})
 	}
 }
 
-func parsePublicKey(keyStr string) (ed25519.PublicKey, error) {
-	var keyBytes []byte
-	var err error
+// isPublic reports whether path is a health endpoint, a webhook or an
+// unsubscribe link; the latter two authenticate callers themselves. The
+// generated router registers them alongside the protected routes, so they
+// pass through here.
+func isPublic(path string) bool {
+	return path == "/health" || strings.HasPrefix(path, "/health/") ||
+		strings.HasPrefix(path, "/webhooks/") || strings.HasPrefix(path, "/unsubscribe/")
+}
 
-	// Try Base64 decode first
-	keyBytes, err = base64.StdEncoding.DecodeString(keyStr)
-	if err != nil {
-		keyBytes = []byte(keyStr)
+// certService resolves the service mapped to a verified client certificate.
+func certService(cfg *config.Config, r *http.Request) (*config.Service, bool) {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return nil, false
 	}
+	return cfg.Registry().LookupSubject(r.TLS.VerifiedChains[0][0].Subject)
+}
 
-	// 1. Try OpenSSH format
-	if bytes.Contains(keyBytes, []byte("ssh-ed25519")) || bytes.Contains(keyBytes, []byte("BEGIN")) {
-		pub, _, _, _, err := ssh.ParseAuthorizedKey(keyBytes)
-		if err == nil {
-			if edKey, ok := pub.(ssh.CryptoPublicKey); ok {
-				if pk, ok := edKey.CryptoPublicKey().(ed25519.PublicKey); ok {
-					return pk, nil
-				}
-			}
-		}
+// readBody buffers the request body up to limit and returns its hex SHA-256.
+// Hashing happens while buffering so the body is only read once. On failure
+// the error response has been written and ok is false.
+func readBody(logger *slog.Logger, w http.ResponseWriter, r *http.Request, limit int64) (hash string, ok bool) {
+	if r.ContentLength > limit {
+		logger.DebugContext(r.Context(), "Auth failed: Content-Length exceeds limit", "content_length", r.ContentLength, "limit", limit)
+		writeTooLarge(w, limit)
+		return "", false
+	}
 
-		// Try as raw SSH body (sometimes folks copy just the base64 part of the pubkey)
-		// But usually it's easier to try parsing as a generic PEM PKIX public key
-		block, _ := pem.Decode(keyBytes)
-		if block != nil {
-			pub, err := x509.ParsePKIXPublicKey(block.Bytes)
-			if err == nil {
-				if pk, ok := pub.(ed25519.PublicKey); ok {
-					return pk, nil
-				}
-			}
+	var body bytes.Buffer
+	hasher := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(&body, hasher), http.MaxBytesReader(w, r.Body, limit)); err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			logger.DebugContext(r.Context(), "Auth failed: body exceeds limit", "limit", limit)
+			writeTooLarge(w, limit)
+			return "", false
 		}
+		logger.DebugContext(r.Context(), "Auth failed: body read error", "error", err)
+		apierror.Write(w, http.StatusBadRequest, apierror.CodeInvalidBody, "Failed to read request body")
+		return "", false
 	}
+	r.Body = io.NopCloser(&body) // Restore body for later handlers
 
-	// 2. Handle raw bytes
-	if len(keyBytes) == ed25519.PublicKeySize {
-		return ed25519.PublicKey(keyBytes), nil
-	}
+	return hex.EncodeToString(hasher.Sum(nil)), true
+}
 
-	return nil, fmt.Errorf("unsupported public key format or invalid size (%d bytes)", len(keyBytes))
+func writeTooLarge(w http.ResponseWriter, limit int64) {
+	apierror.Write(w, http.StatusRequestEntityTooLarge, apierror.CodePayloadTooLarge, "Request body too large",
+		fmt.Sprintf("body: must not exceed %d bytes", limit))
 }
@@ -0,0 +1,291 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/config"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/logging"
+	"github.com/Low-Stack-Technologies/message-delivery-service/pkg/api"
+)
+
+func setupConfig(t *testing.T, extra string) (*config.Store, ed25519.PrivateKey) {
+	t.Helper()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	cfgYAML := "services:\n" +
+		"  - id: \"test-client\"\n" +
+		"    name: \"Test\"\n" +
+		"    public_key: \"" + base64.StdEncoding.EncodeToString(pub) + "\"\n" +
+		extra
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(cfgYAML), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	store := config.NewStore(path)
+	if _, err := store.Load(); err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	return store, priv
+}
+
+func signedRequest(priv ed25519.PrivateKey, clientID, body string, ts time.Time) *http.Request {
+	timestamp := ts.Format(time.RFC3339)
+	bodyHash := sha256.Sum256([]byte(body))
+	canonical := "POST\n/v3/email\n" + timestamp + "\n" + hex.EncodeToString(bodyHash[:])
+	signature := ed25519.Sign(priv, []byte(canonical))
+
+	req := httptest.NewRequest(http.MethodPost, "/v3/email", strings.NewReader(body))
+	req.Header.Set("X-Client-Id", clientID)
+	req.Header.Set("X-Timestamp", timestamp)
+	req.Header.Set("Authorization", "Signature "+base64.StdEncoding.EncodeToString(signature))
+	return req
+}
+
+func TestMiddleware_ErrorResponses(t *testing.T) {
+	t.Parallel()
+
+	store, priv := setupConfig(t, "")
+	_, otherPriv, _ := ed25519.GenerateKey(rand.Reader)
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	})
+	handler := NewMiddleware(store, logging.Discard(), nil)(ok)
+
+	tests := []struct {
+		name       string
+		req        func() *http.Request
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name: "missing headers",
+			req: func() *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/v3/email", strings.NewReader("{}"))
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   "MISSING_AUTH_HEADERS",
+		},
+		{
+			name: "invalid timestamp",
+			req: func() *http.Request {
+				req := signedRequest(priv, "test-client", "{}", time.Now())
+				req.Header.Set("X-Timestamp", "yesterday")
+				return req
+			},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "INVALID_TIMESTAMP",
+		},
+		{
+			name: "expired timestamp",
+			req: func() *http.Request {
+				return signedRequest(priv, "test-client", "{}", time.Now().Add(-10*time.Minute))
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   "TIMESTAMP_EXPIRED",
+		},
+		{
+			name: "unknown client",
+			req: func() *http.Request {
+				return signedRequest(priv, "nobody", "{}", time.Now())
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   "UNKNOWN_CLIENT",
+		},
+		{
+			name: "wrong signature",
+			req: func() *http.Request {
+				return signedRequest(otherPriv, "test-client", "{}", time.Now())
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   "SIGNATURE_INVALID",
+		},
+		{
+			name: "valid signature",
+			req: func() *http.Request {
+				return signedRequest(priv, "test-client", "{}", time.Now())
+			},
+			wantStatus: http.StatusAccepted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, tt.req())
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+			if tt.wantCode == "" {
+				return
+			}
+
+			if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("Expected JSON content type, got %q", ct)
+			}
+			var resp api.ErrorResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("Response is not an ErrorResponse: %v\n%s", err, rec.Body.String())
+			}
+			if resp.Success {
+				t.Error("Expected success to be false")
+			}
+			if resp.Error.Code != tt.wantCode {
+				t.Errorf("Expected code %s, got %s", tt.wantCode, resp.Error.Code)
+			}
+		})
+	}
+}
+
+func TestMiddleware_MissingHeadersDetails(t *testing.T) {
+	t.Parallel()
+
+	store, _ := setupConfig(t, "")
+
+	req := httptest.NewRequest(http.MethodPost, "/v3/email", nil)
+	req.Header.Set("X-Client-Id", "test-client")
+
+	rec := httptest.NewRecorder()
+	NewMiddleware(store, logging.Discard(), nil)(http.NotFoundHandler()).ServeHTTP(rec, req)
+
+	var resp api.ErrorResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Response is not an ErrorResponse: %v", err)
+	}
+	if resp.Error.Details == nil || len(*resp.Error.Details) != 2 {
+		t.Fatalf("Expected details for the two missing headers, got %v", resp.Error.Details)
+	}
+	if !strings.HasPrefix((*resp.Error.Details)[0], "X-Timestamp") {
+		t.Errorf("Expected first detail to name X-Timestamp, got %q", (*resp.Error.Details)[0])
+	}
+}
+
+func TestMiddleware_BodyLimit(t *testing.T) {
+	t.Parallel()
+
+	store, priv := setupConfig(t, "server:\n"+
+		"  max_body_bytes: 16\n"+
+		"  route_body_limits:\n"+
+		"    /v3/sms: 64\n")
+
+	var received string
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		received = string(b)
+		w.WriteHeader(http.StatusAccepted)
+	})
+	handler := NewMiddleware(store, logging.Discard(), nil)(ok)
+
+	// Within the limit the body must reach the handler untouched.
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, signedRequest(priv, "test-client", `{"a":1}`, time.Now()))
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("Expected 202, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if received != `{"a":1}` {
+		t.Errorf("Handler received %q", received)
+	}
+
+	// Declared Content-Length over the limit is rejected up front.
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, signedRequest(priv, "test-client", strings.Repeat("x", 32), time.Now()))
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("Expected 413, got %d", rec.Code)
+	}
+
+	// Unknown length (chunked) is cut off while streaming.
+	req := signedRequest(priv, "test-client", strings.Repeat("x", 32), time.Now())
+	req.ContentLength = -1
+	req.Body = io.NopCloser(strings.NewReader(strings.Repeat("x", 32)))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("Expected 413 for streamed body, got %d", rec.Code)
+	}
+
+	var resp api.ErrorResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Response is not an ErrorResponse: %v", err)
+	}
+	if resp.Error.Code != "PAYLOAD_TOO_LARGE" {
+		t.Errorf("Expected PAYLOAD_TOO_LARGE, got %s", resp.Error.Code)
+	}
+}
+
+func TestMiddleware_ClientCertificate(t *testing.T) {
+	t.Parallel()
+
+	store, _ := setupConfig(t, "  - id: \"billing\"\n"+
+		"    client_cert_subjects: [\"billing.internal\"]\n")
+
+	var got *config.Service
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = ServiceFromContext(r.Context())
+		w.WriteHeader(http.StatusAccepted)
+	})
+	handler := NewMiddleware(store, logging.Discard(), nil)(ok)
+
+	withCert := func(cn string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/v3/email", strings.NewReader("{}"))
+		req.TLS = &tls.ConnectionState{
+			VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: cn}}}},
+		}
+		return req
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, withCert("billing.internal"))
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("Expected 202 for mapped certificate, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if got == nil || got.ID != "billing" {
+		t.Fatalf("Expected billing service in context, got %+v", got)
+	}
+
+	// A mismatching X-Client-Id must not be accepted alongside the certificate.
+	req := withCert("billing.internal")
+	req.Header.Set("X-Client-Id", "test-client")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("Expected 401 for mismatching client ID, got %d", rec.Code)
+	}
+
+	// Unmapped certificates fall back to signature authentication.
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, withCert("someone-else"))
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("Expected 401 for unmapped certificate, got %d", rec.Code)
+	}
+
+	// Certificate-only services cannot authenticate with a signature.
+	_, priv, _ := ed25519.GenerateKey(rand.Reader)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, signedRequest(priv, "billing", "{}", time.Now()))
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("Expected 401 for signature on certificate-only service, got %d", rec.Code)
+	}
+}
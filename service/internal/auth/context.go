@@ -0,0 +1,19 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/config"
+)
+
+type serviceKey struct{}
+
+func withService(ctx context.Context, s *config.Service) context.Context {
+	return context.WithValue(ctx, serviceKey{}, s)
+}
+
+// ServiceFromContext returns the service authenticated by the middleware.
+func ServiceFromContext(ctx context.Context) (*config.Service, bool) {
+	s, ok := ctx.Value(serviceKey{}).(*config.Service)
+	return s, ok
+}
@@ -0,0 +1,182 @@
+package bounce
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/config"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Poller reads reports from the bounce mailbox configured in bounces.pop3.
+type Poller struct {
+	store     *config.Store
+	processor *Processor
+	logger    *slog.Logger
+}
+
+func NewPoller(store *config.Store, processor *Processor, logger *slog.Logger) *Poller {
+	return &Poller{store: store, processor: processor, logger: logger}
+}
+
+// Run polls the mailbox every bounces.pop3.interval until ctx is cancelled.
+// The config is re-read before every poll, so reloads apply to the next one.
+func (p *Poller) Run(ctx context.Context) {
+	for {
+		interval := config.DefaultPOP3Interval
+		if cfg := p.store.Get().Bounces.POP3; cfg.Enabled() {
+			interval = cfg.Interval
+			if n, err := p.Poll(ctx, cfg); err != nil {
+				p.logger.WarnContext(ctx, "Failed to poll bounce mailbox", "host", cfg.Host, "error", err)
+			} else if n > 0 {
+				p.logger.InfoContext(ctx, "Processed bounce reports", "host", cfg.Host, "count", n)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Poll processes the reports in the mailbox and returns how many it
+// processed. Processed reports are deleted; other messages are kept.
+func (p *Poller) Poll(ctx context.Context, cfg config.POP3Config) (processed int, err error) {
+	ctx, span := tracing.Start(ctx, "pop3 poll", attribute.String("server.address", cfg.Host), attribute.Int("server.port", cfg.Port))
+	defer func() { tracing.End(span, err) }()
+
+	c, err := dialPOP3(ctx, cfg)
+	if err != nil {
+		return 0, err
+	}
+	defer c.Close()
+
+	// 1. Log in
+	if _, err := c.cmd("USER %s", cfg.Username); err != nil {
+		return 0, err
+	}
+	if _, err := c.cmd("PASS %s", cfg.Password.Value()); err != nil {
+		return 0, err
+	}
+
+	// 2. Process
+	stat, err := c.cmd("STAT")
+	if err != nil {
+		return 0, err
+	}
+	count, err := strconv.Atoi(strings.Fields(stat + " 0")[0])
+	if err != nil {
+		return 0, fmt.Errorf("pop3: malformed STAT reply %q", stat)
+	}
+	var errs []error
+	for i := 1; i <= count; i++ {
+		raw, err := c.retr(i)
+		if err != nil {
+			return processed, err
+		}
+		events, err := Parse(raw)
+		if errors.Is(err, ErrNotReport) {
+			continue
+		}
+		if err != nil {
+			p.logger.WarnContext(ctx, "Unreadable bounce report", "message", i, "error", err)
+			continue
+		}
+		if err := p.processor.Process(ctx, events); err != nil {
+			// Keep the report to retry on the next poll, but go on with the
+			// others so that their deletions are committed.
+			errs = append(errs, fmt.Errorf("message %d: %w", i, err))
+			continue
+		}
+		if _, err := c.cmd("DELE %d", i); err != nil {
+			return processed, err
+		}
+		processed++
+	}
+
+	// 3. Commit deletions
+	if _, err := c.cmd("QUIT"); err != nil {
+		return processed, err
+	}
+	return processed, errors.Join(errs...)
+}
+
+// pop3Client is a minimal POP3 (RFC 1939) client.
+type pop3Client struct {
+	text *textproto.Conn
+}
+
+// dialPOP3 connects to the mailbox server, securing the connection according
+// to the TLS mode, and reads the greeting.
+func dialPOP3(ctx context.Context, cfg config.POP3Config) (*pop3Client, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)))
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	} else {
+		conn.SetDeadline(time.Now().Add(5 * time.Minute))
+	}
+	tlsConfig := &tls.Config{ServerName: cfg.Host}
+	if cfg.TLSMode() == config.SMTPTLSImplicit {
+		conn = tls.Client(conn, tlsConfig)
+	}
+
+	c := &pop3Client{text: textproto.NewConn(conn)}
+	if _, err := c.reply(); err != nil {
+		c.Close()
+		return nil, err
+	}
+	if cfg.TLSMode() == config.SMTPTLSStartTLS {
+		if _, err := c.cmd("STLS"); err != nil {
+			c.Close()
+			return nil, err
+		}
+		c.text = textproto.NewConn(tls.Client(conn, tlsConfig))
+	}
+	return c, nil
+}
+
+// cmd sends a command and returns the text of its +OK reply.
+func (c *pop3Client) cmd(format string, args ...any) (string, error) {
+	if err := c.text.PrintfLine(format, args...); err != nil {
+		return "", err
+	}
+	return c.reply()
+}
+
+func (c *pop3Client) reply() (string, error) {
+	line, err := c.text.ReadLine()
+	if err != nil {
+		return "", err
+	}
+	if rest, ok := strings.CutPrefix(line, "+OK"); ok {
+		return strings.TrimSpace(rest), nil
+	}
+	return "", fmt.Errorf("pop3: %s", line)
+}
+
+// retr returns message n of the mailbox.
+func (c *pop3Client) retr(n int) ([]byte, error) {
+	if _, err := c.cmd("RETR %d", n); err != nil {
+		return nil, err
+	}
+	return c.text.ReadDotBytes()
+}
+
+func (c *pop3Client) Close() error {
+	return c.text.Close()
+}
@@ -0,0 +1,212 @@
+package bounce
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/config"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/logging"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/storage"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/suppression"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/tracking"
+)
+
+// mockPOP3 is a stand-in POP3 server over a fixed mailbox. It records the
+// commands it receives; deletions are not applied.
+type mockPOP3 struct {
+	Port int
+
+	mailbox  []string
+	mu       sync.Mutex
+	commands []string
+}
+
+func startMockPOP3(t *testing.T, mailbox ...string) *mockPOP3 {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { l.Close() })
+	m := &mockPOP3{Port: l.Addr().(*net.TCPAddr).Port, mailbox: mailbox}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go m.serve(conn)
+		}
+	}()
+	return m
+}
+
+func (m *mockPOP3) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	fmt.Fprint(conn, "+OK POP3 ready\r\n")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		m.mu.Lock()
+		m.commands = append(m.commands, line)
+		m.mu.Unlock()
+
+		verb, arg, _ := strings.Cut(line, " ")
+		switch verb {
+		case "USER":
+			fmt.Fprint(conn, "+OK\r\n")
+		case "PASS":
+			if arg != "secret" {
+				fmt.Fprint(conn, "-ERR [AUTH] Invalid credentials\r\n")
+				continue
+			}
+			fmt.Fprint(conn, "+OK Logged in\r\n")
+		case "STAT":
+			fmt.Fprintf(conn, "+OK %d 0\r\n", len(m.mailbox))
+		case "RETR":
+			var n int
+			fmt.Sscan(arg, &n)
+			if n < 1 || n > len(m.mailbox) {
+				fmt.Fprint(conn, "-ERR No such message\r\n")
+				continue
+			}
+			fmt.Fprint(conn, "+OK\r\n")
+			for _, l := range strings.Split(strings.TrimSuffix(m.mailbox[n-1], "\n"), "\n") {
+				if strings.HasPrefix(l, ".") {
+					l = "." + l
+				}
+				fmt.Fprint(conn, l+"\r\n")
+			}
+			fmt.Fprint(conn, ".\r\n")
+		case "DELE":
+			fmt.Fprint(conn, "+OK Deleted\r\n")
+		case "QUIT":
+			fmt.Fprint(conn, "+OK Bye\r\n")
+			return
+		default:
+			fmt.Fprint(conn, "-ERR Unknown command\r\n")
+		}
+	}
+}
+
+func (m *mockPOP3) Commands() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.commands...)
+}
+
+func TestPoller_Poll(t *testing.T) {
+	ctx := context.Background()
+	reply := "From: a@example.org\nTo: bounces@example.com\nSubject: Re: News\n\n.Please stop.\n"
+	server := startMockPOP3(t, reply, dsn)
+
+	st := storage.NewMemory()
+	messages, suppressions := tracking.NewStore(st), suppression.NewList(st)
+	if err := messages.Record(ctx, &tracking.Message{ID: "lq3k2x9c.5f1e@example.com", Recipients: []tracking.Recipient{
+		{Address: "gone@example.org", Status: tracking.StatusSent},
+		{Address: "full@example.org", Status: tracking.StatusSent},
+		{Address: "slow@example.org", Status: tracking.StatusSent},
+	}}); err != nil {
+		t.Fatalf("Failed to record message: %v", err)
+	}
+
+	cfg := config.POP3Config{Host: "127.0.0.1", Port: server.Port, Username: "bounces", Password: "secret", TLS: "none"}
+	poller := NewPoller(config.NewStaticStore(&config.Config{}), NewProcessor(messages, suppressions, logging.Discard()), logging.Discard())
+	n, err := poller.Poll(ctx, cfg)
+	if err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("Expected one processed report, got %d", n)
+	}
+	want := "USER bounces,PASS secret,STAT,RETR 1,RETR 2,DELE 2,QUIT"
+	if got := strings.Join(server.Commands(), ","); got != want {
+		t.Errorf("Expected commands %s, got %s", want, got)
+	}
+
+	// 1. Message status
+	msg, err := messages.Get(ctx, "lq3k2x9c.5f1e@example.com")
+	if err != nil {
+		t.Fatalf("Failed to get message: %v", err)
+	}
+	var statuses []string
+	for _, r := range msg.Recipients {
+		statuses = append(statuses, r.Address+"="+string(r.Status))
+	}
+	if got := strings.Join(statuses, ","); got != "gone@example.org=BOUNCED,full@example.org=BOUNCED,slow@example.org=DEFERRED" {
+		t.Errorf("Unexpected statuses: %s", got)
+	}
+
+	// 2. Suppression, for the hard bounce only
+	if e, ok, _ := suppressions.Get(ctx, "Gone@example.org", suppression.ScopeGlobal); !ok || e.Reason != suppression.ReasonHardBounce {
+		t.Errorf("Expected gone@example.org to be suppressed for a hard bounce, got %+v", e)
+	}
+	if _, ok, _ := suppressions.Get(ctx, "full@example.org", suppression.ScopeGlobal); ok {
+		t.Error("Expected the soft bounce not to be suppressed")
+	}
+}
+
+// failingStorage fails to store the suppression of one recipient.
+type failingStorage struct {
+	*storage.Memory
+	recipient string
+}
+
+func (s failingStorage) Put(ctx context.Context, bucket, key string, value []byte) error {
+	if bucket == "suppressions" && strings.HasSuffix(key, "/"+s.recipient) {
+		return errors.New("disk full")
+	}
+	return s.Memory.Put(ctx, bucket, key, value)
+}
+
+func TestPoller_PollCommitsDeletionsAfterFailure(t *testing.T) {
+	ctx := context.Background()
+	server := startMockPOP3(t, dsn, arf)
+
+	st := failingStorage{Memory: storage.NewMemory(), recipient: "gone@example.org"}
+	messages := tracking.NewStore(st)
+	if err := messages.Record(ctx, &tracking.Message{ID: "lq3k2x9c.5f1e@example.com", Recipients: []tracking.Recipient{
+		{Address: "gone@example.org", Status: tracking.StatusSent},
+	}}); err != nil {
+		t.Fatalf("Failed to record message: %v", err)
+	}
+	cfg := config.POP3Config{Host: "127.0.0.1", Port: server.Port, Username: "bounces", Password: "secret", TLS: "none"}
+	processor := NewProcessor(messages, suppression.NewList(st), logging.Discard())
+	poller := NewPoller(config.NewStaticStore(&config.Config{}), processor, logging.Discard())
+	n, err := poller.Poll(ctx, cfg)
+	if err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Errorf("Expected the processing error, got %v", err)
+	}
+	if n != 1 {
+		t.Errorf("Expected one processed report, got %d", n)
+	}
+
+	// The failed report is kept for the next poll, the other one is deleted.
+	want := "USER bounces,PASS secret,STAT,RETR 1,RETR 2,DELE 2,QUIT"
+	if got := strings.Join(server.Commands(), ","); got != want {
+		t.Errorf("Expected commands %s, got %s", want, got)
+	}
+}
+
+func TestPoller_PollRejectedLogin(t *testing.T) {
+	server := startMockPOP3(t)
+	poller := NewPoller(config.NewStaticStore(&config.Config{}), nil, logging.Discard())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	cfg := config.POP3Config{Host: "127.0.0.1", Port: server.Port, Username: "bounces", Password: "wrong", TLS: "none"}
+	if _, err := poller.Poll(ctx, cfg); err == nil || !strings.Contains(err.Error(), "Invalid credentials") {
+		t.Errorf("Expected the server's error, got %v", err)
+	}
+}
@@ -0,0 +1,78 @@
+package bounce
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/suppression"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/tracking"
+)
+
+// Processor applies bounce and complaint events.
+type Processor struct {
+	messages     *tracking.Store
+	suppressions *suppression.List
+	logger       *slog.Logger
+}
+
+func NewProcessor(messages *tracking.Store, suppressions *suppression.List, logger *slog.Logger) *Processor {
+	return &Processor{messages: messages, suppressions: suppressions, logger: logger}
+}
+
+// Process updates the status of the messages the events refer to and
+// suppresses hard-bounced and complaining recipients. Anyone can mail the
+// bounce mailbox, so events that match no tracked message and recipient are
+// dropped rather than suppressing an arbitrary address.
+func (p *Processor) Process(ctx context.Context, events []Event) error {
+	var errs []error
+	for _, e := range events {
+		if e.Recipient == "" {
+			p.logger.DebugContext(ctx, "Ignoring bounce without recipient", "message_id", e.MessageID)
+			continue
+		}
+
+		// 1. Message status
+		if err := p.updateStatus(ctx, e); errors.Is(err, tracking.ErrNotFound) {
+			p.logger.WarnContext(ctx, "Dropping bounce for untracked message", "message_id", e.MessageID,
+				"recipient", e.Recipient, "status", e.Status)
+			continue
+		} else if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		p.logger.InfoContext(ctx, "Bounce received", "message_id", e.MessageID, "recipient", e.Recipient,
+			"status", e.Status, "detail", e.Detail)
+
+		// 2. Suppression
+		if e.Suppress {
+			reason := suppression.ReasonHardBounce
+			if e.Status == tracking.StatusComplained {
+				reason = suppression.ReasonComplaint
+			}
+			if err := p.suppressions.Add(ctx, suppression.Entry{Recipient: e.Recipient, Reason: reason, Detail: e.Detail}); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// updateStatus sets the status of the recipient of e in the message it refers
+// to, which is looked up by the local part of its ID when that is all the
+// report identified it by. Events without an ID match no message.
+func (p *Processor) updateStatus(ctx context.Context, e Event) error {
+	id := e.MessageID
+	if id == "" {
+		return tracking.ErrNotFound
+	}
+	if !strings.Contains(id, "@") {
+		msg, err := p.messages.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+		id = msg.ID
+	}
+	return p.messages.Update(ctx, id, e.Recipient, e.Status, e.Detail)
+}
@@ -0,0 +1,108 @@
+package bounce
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/logging"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/storage"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/suppression"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/tracking"
+)
+
+func TestProcessor_FindsMessageByVERPLocalPart(t *testing.T) {
+	ctx := context.Background()
+	st := storage.NewMemory()
+	messages := tracking.NewStore(st)
+	id := "mgwq1x3k2a9c.5f1e0a7b9c2d4e6f8a0b1c3d@notifications.customer-portal.example-company.co.uk"
+	if err := messages.Record(ctx, &tracking.Message{ID: id, Recipients: []tracking.Recipient{
+		{Address: "gone@example.org", Status: tracking.StatusSent},
+	}}); err != nil {
+		t.Fatalf("Failed to record message: %v", err)
+	}
+
+	envelopeFrom, ok := VERPAddress("bounces@notifications.customer-portal.example-company.co.uk", id)
+	local, parsed := ParseVERP(envelopeFrom)
+	if !ok || !parsed {
+		t.Fatalf("Expected %q to be encoded, got %q", id, envelopeFrom)
+	}
+	p := NewProcessor(messages, suppression.NewList(st), logging.Discard())
+	events := []Event{{MessageID: local, Recipient: "gone@example.org", Status: tracking.StatusBounced, Suppress: true}}
+	if err := p.Process(ctx, events); err != nil {
+		t.Fatalf("Failed to process: %v", err)
+	}
+
+	msg, err := messages.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("Failed to get message: %v", err)
+	}
+	if msg.Recipients[0].Status != tracking.StatusBounced {
+		t.Errorf("Expected the recipient to be BOUNCED, got %s", msg.Recipients[0].Status)
+	}
+
+	if _, ok, _ := suppression.NewList(st).Get(ctx, "gone@example.org", suppression.ScopeGlobal); !ok {
+		t.Error("Expected the bounced recipient to be suppressed")
+	}
+
+	// Reports that match no tracked message and recipient are dropped.
+	events = []Event{
+		{MessageID: "unknown", Recipient: "other@example.org", Status: tracking.StatusBounced, Suppress: true},
+		{MessageID: id, Recipient: "stranger@example.org", Status: tracking.StatusComplained, Suppress: true},
+		{Recipient: "anyone@example.org", Status: tracking.StatusBounced, Suppress: true},
+	}
+	if err := p.Process(ctx, events); err != nil {
+		t.Fatalf("Failed to process: %v", err)
+	}
+	for _, recipient := range []string{"other@example.org", "stranger@example.org", "anyone@example.org"} {
+		if _, ok, _ := suppression.NewList(st).Get(ctx, recipient, suppression.ScopeGlobal); ok {
+			t.Errorf("Expected %s not to be suppressed", recipient)
+		}
+	}
+}
+
+func TestProcessor_SurvivesReopen(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "mds.db")
+	st, err := storage.OpenBolt(path)
+	if err != nil {
+		t.Fatalf("Failed to open storage: %v", err)
+	}
+	messages := tracking.NewStore(st)
+	if err := messages.Record(ctx, &tracking.Message{ID: "abc.123@example.com", Recipients: []tracking.Recipient{
+		{Address: "gone@example.org", Status: tracking.StatusSent},
+		{Address: "angry@example.org", Status: tracking.StatusSent},
+	}}); err != nil {
+		t.Fatalf("Failed to record message: %v", err)
+	}
+	p := NewProcessor(messages, suppression.NewList(st), logging.Discard())
+	if err := p.Process(ctx, []Event{
+		{MessageID: "abc.123@example.com", Recipient: "gone@example.org", Status: tracking.StatusBounced, Suppress: true},
+		{MessageID: "abc.123@example.com", Recipient: "angry@example.org", Status: tracking.StatusComplained, Suppress: true},
+	}); err != nil {
+		t.Fatalf("Failed to process: %v", err)
+	}
+	if err := st.Close(); err != nil {
+		t.Fatalf("Failed to close storage: %v", err)
+	}
+
+	// Statuses and suppressions are still there after a restart.
+	st, err = storage.OpenBolt(path)
+	if err != nil {
+		t.Fatalf("Failed to reopen storage: %v", err)
+	}
+	defer st.Close()
+	msg, err := tracking.NewStore(st).Get(ctx, "abc.123@example.com")
+	if err != nil {
+		t.Fatalf("Failed to get message: %v", err)
+	}
+	if msg.Recipients[0].Status != tracking.StatusBounced || msg.Recipients[1].Status != tracking.StatusComplained {
+		t.Errorf("Unexpected statuses: %+v", msg.Recipients)
+	}
+	suppressions := suppression.NewList(st)
+	for recipient, reason := range map[string]string{"gone@example.org": suppression.ReasonHardBounce, "angry@example.org": suppression.ReasonComplaint} {
+		if e, ok, err := suppressions.Check(ctx, recipient, ""); err != nil || !ok || e.Reason != reason {
+			t.Errorf("Expected %s to stay suppressed for %s, got %+v, %v", recipient, reason, e, err)
+		}
+	}
+}
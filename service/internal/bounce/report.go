@@ -0,0 +1,226 @@
+package bounce
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/textproto"
+	"strings"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/tracking"
+)
+
+// ErrNotReport is returned by Parse for messages that are neither delivery
+// status notifications nor feedback reports, such as replies sent to the
+// bounce address.
+var ErrNotReport = errors.New("bounce: not a delivery status notification or feedback report")
+
+// Event is the outcome a report gives for one recipient of a message.
+type Event struct {
+	// MessageID is the Message-ID of the original message, without angle
+	// brackets. It is empty when the report does not identify it, and only
+	// its local part when the report identifies it by a VERP address.
+	MessageID string
+	Recipient string
+	Status    tracking.Status
+	Detail    string
+
+	// Suppress is set for hard bounces and complaints: the recipient must
+	// not be mailed again.
+	Suppress bool
+}
+
+// Parse reads the events of a multipart/report message: a delivery status
+// notification (RFC 3464) or an abuse feedback report (RFC 5965). The
+// original message is identified by its Message-ID in the returned headers,
+// or else by a VERP address the report was delivered to.
+func Parse(raw []byte) ([]Event, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("bounce: %w", err)
+	}
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/report" || params["boundary"] == "" {
+		return nil, ErrNotReport
+	}
+
+	// 1. Parts
+	var events []Event
+	var original textproto.MIMEHeader
+	isReport := false
+	parts := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := parts.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("bounce: %w", err)
+		}
+		partType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		switch partType {
+		case "message/delivery-status", "message/global-delivery-status":
+			isReport = true
+			found, err := deliveryStatus(part)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, found...)
+		case "message/feedback-report":
+			isReport = true
+			found, err := feedbackReport(part)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, found...)
+		case "text/rfc822-headers", "message/rfc822", "message/global", "message/global-headers":
+			original, _ = readFields(bufio.NewReader(part))
+		}
+	}
+	if !isReport {
+		return nil, ErrNotReport
+	}
+
+	// 2. Original message
+	messageID := strings.Trim(strings.TrimSpace(original.Get("Message-Id")), "<>")
+	if messageID == "" {
+		messageID = verpRecipient(msg.Header)
+	}
+	for i := range events {
+		events[i].MessageID = messageID
+		if events[i].Recipient == "" && original != nil {
+			// Feedback reports may omit Original-Rcpt-To.
+			if to, err := mail.ParseAddress(original.Get("To")); err == nil {
+				events[i].Recipient = to.Address
+			}
+		}
+	}
+	return events, nil
+}
+
+// deliveryStatus reads the per-recipient fields of a delivery-status part.
+// The first group of fields describes the message and is skipped.
+func deliveryStatus(r io.Reader) ([]Event, error) {
+	var events []Event
+	br := bufio.NewReader(r)
+	for {
+		fields, err := readFields(br)
+		if recipient := addressField(fields, "Original-Recipient", "Final-Recipient"); recipient != "" {
+			if e, ok := classify(recipient, fields); ok {
+				events = append(events, e)
+			}
+		}
+		if err == io.EOF {
+			return events, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("bounce: delivery status: %w", err)
+		}
+	}
+}
+
+// classify maps the action and status of a recipient to an event. Failures
+// with a permanent (5.x.x) or missing status are hard bounces; transient
+// (4.x.x) failures are soft bounces, which do not suppress the recipient.
+func classify(recipient string, fields textproto.MIMEHeader) (Event, bool) {
+	status := strings.TrimSpace(fields.Get("Status"))
+	detail := typedValue(fields.Get("Diagnostic-Code"))
+	if detail == "" {
+		detail = status
+	}
+	e := Event{Recipient: recipient, Detail: detail}
+	switch strings.ToLower(strings.TrimSpace(fields.Get("Action"))) {
+	case "failed":
+		e.Status = tracking.StatusBounced
+		e.Suppress = !strings.HasPrefix(status, "4.")
+	case "delayed":
+		e.Status = tracking.StatusDeferred
+	case "delivered", "relayed", "expanded":
+		e.Status = tracking.StatusDelivered
+	default:
+		return Event{}, false
+	}
+	return e, true
+}
+
+// feedbackReport reads a feedback-report part. Every feedback type counts as
+// a complaint.
+func feedbackReport(r io.Reader) ([]Event, error) {
+	fields, err := readFields(bufio.NewReader(r))
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("bounce: feedback report: %w", err)
+	}
+	feedbackType := strings.ToLower(strings.TrimSpace(fields.Get("Feedback-Type")))
+	if feedbackType == "" {
+		feedbackType = "abuse"
+	}
+	return []Event{{
+		Recipient: addressField(fields, "Original-Rcpt-To"),
+		Status:    tracking.StatusComplained,
+		Detail:    "feedback-type: " + feedbackType,
+		Suppress:  true,
+	}}, nil
+}
+
+// readFields reads one group of header fields, ending at a blank line or at
+// the end of input. Blank lines before the group are skipped.
+func readFields(br *bufio.Reader) (textproto.MIMEHeader, error) {
+	for {
+		b, err := br.Peek(1)
+		if err != nil {
+			return nil, err
+		}
+		if b[0] != '\r' && b[0] != '\n' {
+			break
+		}
+		br.ReadByte()
+	}
+	fields, err := textproto.NewReader(br).ReadMIMEHeader()
+	if err == io.ErrUnexpectedEOF {
+		// The last group need not end with a blank line.
+		err = io.EOF
+	}
+	return fields, err
+}
+
+// addressField returns the address of the first of names that is set, with
+// the address type ("rfc822;") removed.
+func addressField(fields textproto.MIMEHeader, names ...string) string {
+	for _, name := range names {
+		if v := typedValue(fields.Get(name)); v != "" {
+			return strings.Trim(v, "<>")
+		}
+	}
+	return ""
+}
+
+// typedValue strips the type prefix of a DSN field such as
+// "smtp; 550 5.1.1 User unknown".
+func typedValue(v string) string {
+	if _, value, ok := strings.Cut(v, ";"); ok {
+		v = value
+	}
+	return strings.TrimSpace(v)
+}
+
+// verpRecipient finds the local part of the message ID in the VERP address a
+// report was delivered to.
+func verpRecipient(header mail.Header) string {
+	for _, name := range []string{"Delivered-To", "X-Original-To", "Envelope-To", "To"} {
+		addrs, err := header.AddressList(name)
+		if err != nil {
+			continue
+		}
+		for _, addr := range addrs {
+			if id, ok := ParseVERP(addr.Address); ok {
+				return id
+			}
+		}
+	}
+	return ""
+}
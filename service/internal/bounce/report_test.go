@@ -0,0 +1,140 @@
+package bounce
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/tracking"
+)
+
+// dsn is a delivery status notification as sent by Postfix, with one hard
+// bounce, one soft bounce and one delay.
+const dsn = `From: MAILER-DAEMON@mx.example.net
+To: bounces+lq3k2x9c.5f1e@example.com
+Subject: Undelivered Mail Returned to Sender
+MIME-Version: 1.0
+Content-Type: multipart/report; report-type=delivery-status;
+	boundary="B1"
+
+--B1
+Content-Type: text/plain
+
+I'm sorry to have to inform you that your message could not be delivered.
+
+--B1
+Content-Type: message/delivery-status
+
+Reporting-MTA: dns; mx.example.net
+Arrival-Date: Mon, 19 Oct 2026 10:00:00 +0000
+
+Final-Recipient: rfc822; Gone@example.org
+Original-Recipient: rfc822;gone@example.org
+Action: failed
+Status: 5.1.1
+Diagnostic-Code: smtp; 550 5.1.1 <gone@example.org>: Recipient address
+    rejected: User unknown
+
+Final-Recipient: rfc822; full@example.org
+Action: failed
+Status: 4.2.2
+Diagnostic-Code: smtp; 452 4.2.2 Mailbox full
+
+Final-Recipient: rfc822; slow@example.org
+Action: delayed
+Status: 4.4.1
+
+--B1
+Content-Type: text/rfc822-headers
+
+From: news@example.com
+To: gone@example.org, full@example.org, slow@example.org
+Subject: News
+Message-ID: <lq3k2x9c.5f1e@example.com>
+
+--B1--
+`
+
+// arf is an abuse report in the Abuse Reporting Format.
+const arf = `From: feedback@mailbox.example.net
+To: bounces@example.com
+Subject: Complaint
+MIME-Version: 1.0
+Content-Type: multipart/report; report-type=feedback-report; boundary="B2"
+
+--B2
+Content-Type: text/plain
+
+This is an email abuse report.
+
+--B2
+Content-Type: message/feedback-report
+
+Feedback-Type: abuse
+User-Agent: ExampleFBL/1.0
+Version: 1
+
+--B2
+Content-Type: message/rfc822
+
+From: news@example.com
+To: Reader <reader@example.net>
+Subject: News
+Message-ID: <abc.123@example.com>
+
+Read all about it.
+--B2--
+`
+
+func TestParse_DeliveryStatus(t *testing.T) {
+	events, err := Parse([]byte(strings.ReplaceAll(dsn, "\n", "\r\n")))
+	if err != nil {
+		t.Fatalf("Failed to parse DSN: %v", err)
+	}
+	want := []Event{
+		{MessageID: "lq3k2x9c.5f1e@example.com", Recipient: "gone@example.org", Status: tracking.StatusBounced,
+			Detail: "550 5.1.1 <gone@example.org>: Recipient address rejected: User unknown", Suppress: true},
+		{MessageID: "lq3k2x9c.5f1e@example.com", Recipient: "full@example.org", Status: tracking.StatusBounced,
+			Detail: "452 4.2.2 Mailbox full"},
+		{MessageID: "lq3k2x9c.5f1e@example.com", Recipient: "slow@example.org", Status: tracking.StatusDeferred,
+			Detail: "4.4.1"},
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("Expected %+v, got %+v", want, events)
+	}
+}
+
+func TestParse_VERPWithoutOriginalHeaders(t *testing.T) {
+	raw, _, _ := strings.Cut(dsn, "--B1\nContent-Type: text/rfc822-headers")
+	events, err := Parse([]byte(raw + "--B1--\n"))
+	if err != nil {
+		t.Fatalf("Failed to parse DSN: %v", err)
+	}
+	if len(events) != 3 || events[0].MessageID != "lq3k2x9c.5f1e" {
+		t.Errorf("Expected the message ID local part from the VERP address, got %+v", events)
+	}
+}
+
+func TestParse_FeedbackReport(t *testing.T) {
+	events, err := Parse([]byte(arf))
+	if err != nil {
+		t.Fatalf("Failed to parse report: %v", err)
+	}
+	want := []Event{{MessageID: "abc.123@example.com", Recipient: "reader@example.net", Status: tracking.StatusComplained,
+		Detail: "feedback-type: abuse", Suppress: true}}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("Expected %+v, got %+v", want, events)
+	}
+}
+
+func TestParse_NotReport(t *testing.T) {
+	for name, raw := range map[string]string{
+		"reply":     "From: a@example.org\nTo: bounces@example.com\nSubject: Re: News\n\nPlease stop.\n",
+		"multipart": "Content-Type: multipart/mixed; boundary=X\n\n--X\nContent-Type: text/plain\n\nHi\n--X--\n",
+	} {
+		if _, err := Parse([]byte(raw)); !errors.Is(err, ErrNotReport) {
+			t.Errorf("%s: expected ErrNotReport, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+// Package bounce processes delivery status notifications (RFC 3464) and spam
+// complaints (RFC 5965): it updates the status of the messages they refer to
+// and suppresses recipients that must not be mailed again.
+package bounce
+
+import (
+	"strings"
+)
+
+// maxLocalPart is the longest local part RFC 5321 requires servers to accept.
+const maxLocalPart = 64
+
+// VERPAddress returns the envelope sender for messageID under VERP: for
+// "bounces@example.com" and "abc@example.org" it is
+// "bounces+abc@example.com". Only the local part of the message ID is
+// encoded, which keeps the address short whatever the domains; bounces sent to
+// it identify the message even when the report does not. It returns
+// envelopeFrom unchanged and false when the local part of the message ID
+// cannot be encoded in a valid local part.
+func VERPAddress(envelopeFrom, messageID string) (string, bool) {
+	at := strings.LastIndex(envelopeFrom, "@")
+	id, _, ok := strings.Cut(messageID, "@")
+	if at < 0 || !ok || !verpSafe(id) {
+		return envelopeFrom, false
+	}
+	local := envelopeFrom[:at] + "+" + id
+	if len(local) > maxLocalPart {
+		return envelopeFrom, false
+	}
+	return local + envelopeFrom[at:], true
+}
+
+// ParseVERP extracts the local part of the message ID from an address built
+// by VERPAddress. Messages are looked up by it with tracking.Store.Find.
+func ParseVERP(address string) (messageLocal string, ok bool) {
+	at := strings.LastIndex(address, "@")
+	if at < 0 {
+		return "", false
+	}
+	plus := strings.LastIndex(address[:at], "+")
+	if plus < 0 {
+		return "", false
+	}
+	token := address[plus+1 : at]
+	if !verpSafe(token) {
+		return "", false
+	}
+	return token, true
+}
+
+// verpSafe reports whether the local part of a message ID is not empty and
+// only has characters that survive in a local part without quoting.
+func verpSafe(id string) bool {
+	if id == "" {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '.', c == '-', c == '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,40 @@
+package bounce
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVERP(t *testing.T) {
+	const from = "bounces@mail.example.com"
+	tests := []struct {
+		envelopeFrom string
+		messageID    string
+		want         string
+	}{
+		{from, "lq3k2x9c.5f1e0a7b@example.com", "bounces+lq3k2x9c.5f1e0a7b@mail.example.com"},
+		{"bounces@notifications.customer-portal.example-company.co.uk", "mgwq1x3k2a9c.5f1e0a7b9c2d4e6f8a0b1c3d@notifications.customer-portal.example-company.co.uk",
+			"bounces+mgwq1x3k2a9c.5f1e0a7b9c2d4e6f8a0b1c3d@notifications.customer-portal.example-company.co.uk"},
+		{from, "has+plus@example.com", from},
+		{from, "no-at", from},
+		{from, strings.Repeat("a", 60) + "@example.com", from},
+	}
+	for _, tt := range tests {
+		t.Run(tt.messageID, func(t *testing.T) {
+			got, encoded := VERPAddress(tt.envelopeFrom, tt.messageID)
+			if got != tt.want || encoded != (got != tt.envelopeFrom) {
+				t.Fatalf("Expected %q, got %q, %v", tt.want, got, encoded)
+			}
+			local, ok := ParseVERP(got)
+			if want, _, _ := strings.Cut(tt.messageID, "@"); ok != encoded || (ok && local != want) {
+				t.Errorf("Expected %q to decode to %q, got %q, %v", got, want, local, ok)
+			}
+		})
+	}
+
+	for _, addr := range []string{"user@example.com", "user+=x@example.com", "user+@example.com", "no-at"} {
+		if id, ok := ParseVERP(addr); ok {
+			t.Errorf("Expected %q not to decode, got %q", addr, id)
+		}
+	}
+}
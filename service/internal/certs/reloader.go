@@ -0,0 +1,174 @@
+// Package certs serves TLS certificates that can be rotated on disk without
+// restarting the server.
+package certs
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/config"
+)
+
+// DefaultCheckInterval is how often Watch looks for changed certificate files.
+const DefaultCheckInterval = 30 * time.Second
+
+// Reloader holds the current server certificate and client CA pool. Every TLS
+// handshake reads the latest state, so a successful Reload applies to new
+// connections immediately while existing connections are unaffected.
+type Reloader struct {
+	logger *slog.Logger
+
+	mu         sync.RWMutex
+	cert       *tls.Certificate
+	clientCAs  *x509.CertPool
+	clientAuth tls.ClientAuthType
+
+	// Fingerprint of the files behind the current state, used to skip
+	// reloads when nothing changed.
+	loaded fileSet
+}
+
+type fileSet struct {
+	cfg      config.TLSConfig
+	modTimes [3]time.Time
+}
+
+// NewReloader loads the certificates described by cfg.
+func NewReloader(cfg config.TLSConfig, logger *slog.Logger) (*Reloader, error) {
+	r := &Reloader{logger: logger}
+	if err := r.Reload(cfg); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload re-reads the certificate, key and client CA files if they or their
+// paths changed. On error the previously loaded state is kept.
+func (r *Reloader) Reload(cfg config.TLSConfig) error {
+	files, err := stat(cfg)
+	if err != nil {
+		return err
+	}
+
+	r.mu.RLock()
+	unchanged := r.cert != nil && r.loaded == files
+	r.mu.RUnlock()
+	if unchanged {
+		return nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load server certificate: %w", err)
+	}
+
+	var pool *x509.CertPool
+	if cfg.ClientCAFile != "" {
+		pem, err := os.ReadFile(cfg.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read client CA: %w", err)
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificates found in client CA file %s", cfg.ClientCAFile)
+		}
+	}
+
+	r.mu.Lock()
+	r.cert = &cert
+	r.clientCAs = pool
+	r.clientAuth = clientAuthType(cfg)
+	r.loaded = files
+	r.mu.Unlock()
+
+	r.logger.Info("TLS certificates loaded", "cert", cfg.CertFile, "client_ca", cfg.ClientCAFile)
+	return nil
+}
+
+// TLSConfig returns a server config that resolves the certificate and client
+// verification settings per handshake.
+func (r *Reloader) TLSConfig() *tls.Config {
+	nextProtos := []string{"h2", "http/1.1"}
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: nextProtos,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+			return r.cert, nil
+		},
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+			return &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				NextProtos:   nextProtos,
+				Certificates: []tls.Certificate{*r.cert},
+				ClientCAs:    r.clientCAs,
+				ClientAuth:   r.clientAuth,
+			}, nil
+		},
+	}
+}
+
+// Watch periodically reloads certificates using the TLS settings of the
+// store's active config until ctx is cancelled.
+func (r *Reloader) Watch(ctx context.Context, store *config.Store, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			cfg := store.Get()
+			if cfg == nil || !cfg.Server.TLS.Enabled() {
+				continue
+			}
+			if err := r.Reload(cfg.Server.TLS); err != nil {
+				r.logger.Error("Failed to reload TLS certificates", "error", err)
+			}
+		}
+	}
+}
+
+func stat(cfg config.TLSConfig) (fileSet, error) {
+	files := fileSet{cfg: cfg}
+	for i, path := range []string{cfg.CertFile, cfg.KeyFile, cfg.ClientCAFile} {
+		if path == "" {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return fileSet{}, fmt.Errorf("failed to stat %s: %w", path, err)
+		}
+		files.modTimes[i] = info.ModTime()
+	}
+	return files, nil
+}
+
+func clientAuthType(cfg config.TLSConfig) tls.ClientAuthType {
+	switch cfg.ClientAuth {
+	case "request":
+		return tls.RequestClientCert
+	case "verify_if_given":
+		return tls.VerifyClientCertIfGiven
+	case "require":
+		return tls.RequireAndVerifyClientCert
+	case "none":
+		return tls.NoClientCert
+	}
+	// A client CA without an explicit mode accepts, but does not require,
+	// client certificates.
+	if cfg.ClientCAFile != "" {
+		return tls.VerifyClientCertIfGiven
+	}
+	return tls.NoClientCert
+}
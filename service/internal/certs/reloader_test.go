@@ -0,0 +1,219 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/config"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/logging"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func issue(t *testing.T, cn string, parent *testCert, isCA bool) *testCert {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	serial, _ := rand.Int(rand.Reader, big.NewInt(1<<62))
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+	}
+
+	signer, signerKey := tmpl, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return &testCert{
+		cert: cert,
+		key:  key,
+		pem:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	}
+}
+
+func (c *testCert) keyPEM(t *testing.T) []byte {
+	der, err := x509.MarshalECPrivateKey(c.key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+}
+
+func (c *testCert) write(t *testing.T, certPath, keyPath string, mtime time.Time) {
+	t.Helper()
+	if err := os.WriteFile(certPath, c.pem, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyPath, c.keyPEM(t), 0600); err != nil {
+		t.Fatal(err)
+	}
+	// Force distinct modification times so the change is always detected.
+	os.Chtimes(certPath, mtime, mtime)
+	os.Chtimes(keyPath, mtime, mtime)
+}
+
+// handshake connects to a TLS listener served by r and returns the server
+// certificate the client saw and the verified chains the server saw.
+func handshake(t *testing.T, r *Reloader, roots *x509.CertPool, clientCert *tls.Certificate) (*x509.Certificate, [][]*x509.Certificate) {
+	t.Helper()
+
+	l, err := tls.Listen("tcp", "127.0.0.1:0", r.TLSConfig())
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer l.Close()
+
+	chains := make(chan [][]*x509.Certificate, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			chains <- nil
+			return
+		}
+		defer conn.Close()
+		tlsConn := conn.(*tls.Conn)
+		if err := tlsConn.Handshake(); err != nil {
+			chains <- nil
+			return
+		}
+		chains <- tlsConn.ConnectionState().VerifiedChains
+	}()
+
+	clientCfg := &tls.Config{RootCAs: roots, ServerName: "127.0.0.1"}
+	if clientCert != nil {
+		clientCfg.Certificates = []tls.Certificate{*clientCert}
+	}
+	conn, err := tls.Dial("tcp", l.Addr().String(), clientCfg)
+	if err != nil {
+		t.Fatalf("Handshake failed: %v", err)
+	}
+	defer conn.Close()
+
+	return conn.ConnectionState().PeerCertificates[0], <-chains
+}
+
+func TestReloader_RotatesServerCertificate(t *testing.T) {
+	dir := t.TempDir()
+	certPath := filepath.Join(dir, "tls.crt")
+	keyPath := filepath.Join(dir, "tls.key")
+
+	ca := issue(t, "Test CA", nil, true)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+
+	first := issue(t, "first", ca, false)
+	first.write(t, certPath, keyPath, time.Now().Add(-time.Minute))
+
+	cfg := config.TLSConfig{CertFile: certPath, KeyFile: keyPath}
+	r, err := NewReloader(cfg, logging.Discard())
+	if err != nil {
+		t.Fatalf("NewReloader failed: %v", err)
+	}
+
+	if got, _ := handshake(t, r, roots, nil); got.Subject.CommonName != "first" {
+		t.Fatalf("Expected first certificate, got %s", got.Subject.CommonName)
+	}
+
+	second := issue(t, "second", ca, false)
+	second.write(t, certPath, keyPath, time.Now())
+	if err := r.Reload(cfg); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+
+	if got, _ := handshake(t, r, roots, nil); got.Subject.CommonName != "second" {
+		t.Fatalf("Expected rotated certificate, got %s", got.Subject.CommonName)
+	}
+}
+
+func TestReloader_KeepsCertificateOnBrokenReload(t *testing.T) {
+	dir := t.TempDir()
+	certPath := filepath.Join(dir, "tls.crt")
+	keyPath := filepath.Join(dir, "tls.key")
+
+	ca := issue(t, "Test CA", nil, true)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+
+	issue(t, "good", ca, false).write(t, certPath, keyPath, time.Now().Add(-time.Minute))
+	cfg := config.TLSConfig{CertFile: certPath, KeyFile: keyPath}
+	r, err := NewReloader(cfg, logging.Discard())
+	if err != nil {
+		t.Fatalf("NewReloader failed: %v", err)
+	}
+
+	os.WriteFile(certPath, []byte("not a certificate"), 0644)
+	if err := r.Reload(cfg); err == nil {
+		t.Fatal("Expected reload of a broken certificate to fail")
+	}
+
+	if got, _ := handshake(t, r, roots, nil); got.Subject.CommonName != "good" {
+		t.Fatalf("Expected previous certificate to be kept, got %s", got.Subject.CommonName)
+	}
+}
+
+func TestReloader_VerifiesClientCertificates(t *testing.T) {
+	dir := t.TempDir()
+	certPath := filepath.Join(dir, "tls.crt")
+	keyPath := filepath.Join(dir, "tls.key")
+	caPath := filepath.Join(dir, "clients.crt")
+
+	ca := issue(t, "Test CA", nil, true)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	issue(t, "server", ca, false).write(t, certPath, keyPath, time.Now())
+	os.WriteFile(caPath, ca.pem, 0644)
+
+	r, err := NewReloader(config.TLSConfig{
+		CertFile:     certPath,
+		KeyFile:      keyPath,
+		ClientCAFile: caPath,
+		ClientAuth:   "require",
+	}, logging.Discard())
+	if err != nil {
+		t.Fatalf("NewReloader failed: %v", err)
+	}
+
+	client := issue(t, "billing", ca, false)
+	clientPair, err := tls.X509KeyPair(client.pem, client.keyPEM(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, chains := handshake(t, r, roots, &clientPair)
+	if len(chains) == 0 {
+		t.Fatal("Server did not verify the client certificate")
+	}
+	if cn := chains[0][0].Subject.CommonName; cn != "billing" {
+		t.Errorf("Expected client CN billing, got %s", cn)
+	}
+}
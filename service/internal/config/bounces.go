@@ -0,0 +1,79 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// BouncesConfig ingests delivery status notifications (RFC 3464) and spam
+// complaints, from a bounce mailbox or from provider webhooks.
+type BouncesConfig struct {
+	// WebhookSecret authorizes POST /webhooks/bounces, as a bearer token or
+	// basic-auth password. The endpoint is disabled when it is empty.
+	WebhookSecret Secret `yaml:"webhook_secret"`
+
+	// POP3 polls the mailbox that envelope senders point to.
+	POP3 POP3Config `yaml:"pop3"`
+}
+
+// POP3Config polls a bounce mailbox. Recognized reports are deleted from the
+// mailbox once processed; other messages are left in place.
+type POP3Config struct {
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	Username string `yaml:"username"`
+	Password Secret `yaml:"password"`
+	TLS      string `yaml:"tls"` // implicit (default on 995), starttls or none
+
+	// Interval is the time between polls (default 1m).
+	Interval time.Duration `yaml:"interval"`
+}
+
+// Enabled reports whether a bounce mailbox is configured.
+func (c POP3Config) Enabled() bool {
+	return c.Host != ""
+}
+
+// TLSMode returns the configured TLS mode, or the default for the port.
+func (c POP3Config) TLSMode() string {
+	if c.TLS != "" {
+		return strings.ToLower(c.TLS)
+	}
+	if c.Port == 995 {
+		return SMTPTLSImplicit
+	}
+	return SMTPTLSStartTLS
+}
+
+// DefaultPOP3Interval applies when bounces.pop3.interval is not set.
+const DefaultPOP3Interval = time.Minute
+
+func (c POP3Config) problems() []string {
+	if !c.Enabled() {
+		if c != (POP3Config{}) {
+			return []string{"host: is required"}
+		}
+		return nil
+	}
+
+	var problems []string
+	if c.Port < 1 || c.Port > 65535 {
+		problems = append(problems, fmt.Sprintf("port: must be between 1 and 65535, got %d", c.Port))
+	}
+	if c.Username == "" {
+		problems = append(problems, "username: is required")
+	}
+	if c.Password == "" {
+		problems = append(problems, "password: is required")
+	}
+	switch c.TLSMode() {
+	case SMTPTLSImplicit, SMTPTLSStartTLS, SMTPTLSNone:
+	default:
+		problems = append(problems, fmt.Sprintf("tls: unknown value %q (expected implicit, starttls or none)", c.TLS))
+	}
+	if c.Interval < 0 {
+		problems = append(problems, "interval: must not be negative")
+	}
+	return problems
+}
@@ -2,24 +2,28 @@ package config
 
 import (
 	"fmt"
-	"log"
 	"os"
+	"strings"
 	"sync"
+	"time"
 
-	"github.com/fsnotify/fsnotify"
 	"gopkg.in/yaml.v3"
 )
 
 type Config struct {
 	mu sync.RWMutex
 
-	Server struct {
-		Host string `yaml:"host"`
-		Port int    `yaml:"port"`
-	} `yaml:"server"`
+	Server ServerConfig `yaml:"server"`
 
+	// Debug is the legacy switch for logging.level: debug.
 	Debug bool `yaml:"debug"`
 
+	Logging LoggingConfig `yaml:"logging"`
+
+	Tracing TracingConfig `yaml:"tracing"`
+
+	Health HealthConfig `yaml:"health"`
+
 	Services []ServiceConfig `yaml:"services"`
 
 	EmailAccounts []EmailAccountConfig `yaml:"email_accounts"`
@@ -27,136 +31,460 @@ type Config struct {
 	Sms struct {
 		FortySixElks FortySixElksConfig `yaml:"46elks"`
 	} `yaml:"sms"`
+
+	Bounces BouncesConfig `yaml:"bounces"`
+
+	Unsubscribe UnsubscribeConfig `yaml:"unsubscribe"`
+
+	Storage StorageConfig `yaml:"storage"`
+
+	registry *ServiceRegistry
+	version  Version
+}
+
+type ServerConfig struct {
+	Host string `yaml:"host"`
+	Port int    `yaml:"port"`
+
+	// MaxBodyBytes caps request bodies on every route. RouteBodyLimits
+	// overrides it for individual paths such as "/v3/email".
+	MaxBodyBytes    int64            `yaml:"max_body_bytes"`
+	RouteBodyLimits map[string]int64 `yaml:"route_body_limits"`
+
+	TLS TLSConfig `yaml:"tls"`
+}
+
+// LoggingConfig selects the log level and output format. The level applies on
+// reload; the format is fixed at startup.
+type LoggingConfig struct {
+	Level  string `yaml:"level"`  // debug, info, warn or error
+	Format string `yaml:"format"` // text or json
+}
+
+// TracingConfig exports OpenTelemetry traces over OTLP/HTTP. Changes apply
+// on restart.
+type TracingConfig struct {
+	Enabled bool `yaml:"enabled"`
+
+	// Endpoint is the collector's base URL, e.g. "http://otel-collector:4318".
+	// When empty the standard OTEL_EXPORTER_OTLP_* variables apply.
+	Endpoint string            `yaml:"endpoint"`
+	Headers  map[string]Secret `yaml:"headers"`
+
+	ServiceName string `yaml:"service_name"`
+
+	// SampleRatio is the fraction of new traces recorded (default 1).
+	// Requests that arrive with a traceparent follow the caller's decision.
+	SampleRatio *float64 `yaml:"sample_ratio"`
+}
+
+// HealthConfig tunes the dependency probes behind /health/ready.
+type HealthConfig struct {
+	// Interval is how long probe results are reused (default 30s).
+	Interval time.Duration `yaml:"interval"`
+
+	// Timeout bounds each probe (default 5s).
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// TLSConfig enables HTTPS serving. Certificate files are re-read when they
+// change on disk, so rotated certificates apply without a restart.
+type TLSConfig struct {
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
+
+	// ClientCAFile enables mutual TLS: client certificates are verified
+	// against this bundle according to ClientAuth.
+	ClientCAFile string `yaml:"client_ca_file"`
+	ClientAuth   string `yaml:"client_auth"` // none, request, verify_if_given or require
+}
+
+// Enabled reports whether HTTPS is configured.
+func (t TLSConfig) Enabled() bool {
+	return t.CertFile != "" || t.KeyFile != ""
 }
 
 type ServiceConfig struct {
 	ID        string `yaml:"id"`
 	Name      string `yaml:"name"`
 	PublicKey string `yaml:"public_key"`
+
+	// ClientCertSubjects authenticate the service by verified client
+	// certificate instead of a request signature. Entries match either the
+	// full subject DN (e.g. "CN=billing,O=Acme") or just the common name.
+	ClientCertSubjects []string `yaml:"client_cert_subjects"`
+
+	// Admin grants access to the /admin endpoints.
+	Admin bool `yaml:"admin"`
+
+	// QuietHours defers the service's SMS outside OTP messages.
+	QuietHours QuietHoursConfig `yaml:"quiet_hours"`
 }
 
+// EmailAccountConfig sends the email of the senders it matches. Address is
+// either an exact address or "*@domain" for every address in the domain;
+// AddressRegex matches the whole sender address instead. Exact addresses take
+// precedence, then "*@domain" and regex accounts in config order, then the
+// Default account.
 type EmailAccountConfig struct {
-	Address string `yaml:"address"`
-	SMTP    struct {
-		Host     string `yaml:"host"`
-		Port     int    `yaml:"port"`
-		Username string `yaml:"username"`
-		Password string `yaml:"password"`
-	} `yaml:"smtp"`
+	Address      string `yaml:"address"`
+	AddressRegex string `yaml:"address_regex"`
+	Default      bool   `yaml:"default"`
+
+	// EnvelopeFrom is the SMTP envelope sender (MAIL FROM), where bounces are
+	// returned. The default is the message's From address.
+	EnvelopeFrom string `yaml:"envelope_from"`
+
+	// VERP encodes the local part of the message ID in the envelope sender
+	// ("bounces+<id>@domain" for EnvelopeFrom "bounces@domain"), so that
+	// bounces can be matched to their message even without a usable report.
+	VERP bool `yaml:"verp"`
+
+	SMTP SMTPConfig `yaml:"smtp"`
+	DKIM DKIMConfig `yaml:"dkim"`
+}
+
+// Name identifies the account in logs and health checks: its address, its
+// regex or "default".
+func (a EmailAccountConfig) Name() string {
+	switch {
+	case a.Address != "":
+		return a.Address
+	case a.AddressRegex != "":
+		return "regex:" + a.AddressRegex
+	}
+	return "default"
+}
+
+// Domain returns the domain of an exact or "*@domain" address, or "".
+func (a EmailAccountConfig) Domain() string {
+	if a.Address == "" {
+		return ""
+	}
+	return addressDomain(a.Address)
+}
+
+// DomainWildcard is the local part of an Address that matches a whole domain.
+const DomainWildcard = "*"
+
+// addressDomain returns the part of address after the last @, in lower case.
+func addressDomain(address string) string {
+	return strings.ToLower(address[strings.LastIndex(address, "@")+1:])
+}
+
+type SMTPConfig struct {
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	Username string `yaml:"username"`
+	Password Secret `yaml:"password"`
+
+	// TLS selects transport security: implicit, starttls (upgrade when the
+	// server offers it), starttls_required or none. The default is implicit
+	// on port 465 and starttls otherwise.
+	TLS string `yaml:"tls"`
+
+	// CAFile verifies the server against this bundle instead of the system
+	// roots. ServerName overrides the name checked in its certificate.
+	CAFile     string `yaml:"ca_file"`
+	ServerName string `yaml:"server_name"`
+
+	// Auth selects the SASL mechanism: PLAIN, LOGIN, CRAM-MD5, XOAUTH2 or
+	// none. The default is XOAUTH2 when OAuth2 is configured and otherwise
+	// PLAIN when a username is set.
+	Auth string `yaml:"auth"`
+
+	// OAuth2 obtains the XOAUTH2 access token. Without it the password is
+	// used as the token.
+	OAuth2 SMTPOAuth2Config `yaml:"oauth2"`
+
+	Pool SMTPPoolConfig `yaml:"pool"`
+}
+
+// SMTP transport security modes.
+const (
+	SMTPTLSImplicit         = "implicit"
+	SMTPTLSStartTLS         = "starttls"
+	SMTPTLSStartTLSRequired = "starttls_required"
+	SMTPTLSNone             = "none"
+)
+
+// SMTP authentication mechanisms.
+const (
+	SMTPAuthPlain   = "PLAIN"
+	SMTPAuthLogin   = "LOGIN"
+	SMTPAuthCRAMMD5 = "CRAM-MD5"
+	SMTPAuthXOAuth2 = "XOAUTH2"
+	SMTPAuthNone    = "NONE"
+)
+
+// TLSMode returns the configured TLS mode, or the default for the port.
+func (c SMTPConfig) TLSMode() string {
+	if c.TLS != "" {
+		return strings.ToLower(c.TLS)
+	}
+	if c.Port == 465 {
+		return SMTPTLSImplicit
+	}
+	return SMTPTLSStartTLS
+}
+
+// AuthMechanism returns the configured mechanism in upper case, or the
+// default: XOAUTH2 with OAuth2, PLAIN with a username and NONE without.
+func (c SMTPConfig) AuthMechanism() string {
+	if c.Auth != "" {
+		return strings.ToUpper(c.Auth)
+	}
+	if c.OAuth2.Enabled() {
+		return SMTPAuthXOAuth2
+	}
+	if c.Username != "" {
+		return SMTPAuthPlain
+	}
+	return SMTPAuthNone
+}
+
+// SMTPOAuth2Config fetches access tokens from an OAuth2 token endpoint: with
+// the refresh-token grant when RefreshToken is set and the client-credentials
+// grant otherwise. Tokens are cached and renewed RefreshBefore their expiry.
+type SMTPOAuth2Config struct {
+	TokenURL      string        `yaml:"token_url"`
+	ClientID      string        `yaml:"client_id"`
+	ClientSecret  Secret        `yaml:"client_secret"`
+	RefreshToken  Secret        `yaml:"refresh_token"`
+	Scope         string        `yaml:"scope"`          // space-separated
+	RefreshBefore time.Duration `yaml:"refresh_before"` // default 5m
+}
+
+// Enabled reports whether a token endpoint is configured.
+func (c SMTPOAuth2Config) Enabled() bool {
+	return c.TokenURL != ""
+}
+
+// SMTPPoolConfig bounds the sessions kept open to an account's server.
+// Messages wait for a free session when all of them are busy.
+type SMTPPoolConfig struct {
+	MaxConnections int           `yaml:"max_connections"` // default 2
+	IdleTimeout    time.Duration `yaml:"idle_timeout"`    // default 60s
 }
 
 type FortySixElksConfig struct {
 	Username string `yaml:"username"`
-	Password string `yaml:"password"`
+	Password Secret `yaml:"password"`
 }
 
-var (
-	currentConfig *Config
-	configMutex   sync.RWMutex
+// DefaultMaxBodyBytes applies when server.max_body_bytes is not set.
+const DefaultMaxBodyBytes int64 = 1 << 20
+
+// Defaults for the health section.
+const (
+	DefaultHealthInterval = 30 * time.Second
+	DefaultHealthTimeout  = 5 * time.Second
+)
+
+// Defaults for email_accounts[].smtp.pool.
+const (
+	DefaultSMTPMaxConnections = 2
+	DefaultSMTPIdleTimeout    = 60 * time.Second
 )
 
+// DefaultOAuth2RefreshBefore applies when email_accounts[].smtp.oauth2 is
+// configured without refresh_before.
+const DefaultOAuth2RefreshBefore = 5 * time.Minute
+
 const defaultConfig = `# Message Delivery Service Configuration
 
 server:
   host: "0.0.0.0"
   port: 3000
+  # Maximum accepted request body size in bytes (default 1 MiB)
+  max_body_bytes: 1048576
+  # Per-route overrides
+  # route_body_limits:
+  #   /v3/email: 10485760
+  # Serve HTTPS directly (certificates are reloaded when the files change)
+  # tls:
+  #   cert_file: "/etc/mds/tls.crt"
+  #   key_file: "/etc/mds/tls.key"
+  #   client_ca_file: "/etc/mds/clients-ca.crt" # Enables mutual TLS
+  #   client_auth: "verify_if_given" # none, request, verify_if_given, require
+
+# Logs mask email addresses, phone numbers and signatures.
+logging:
+  level: "info" # debug, info, warn, error
+  format: "text" # text or json
+
+# OpenTelemetry tracing (OTLP over HTTP). Incoming traceparent headers are
+# continued; changes apply on restart.
+# tracing:
+#   enabled: true
+#   endpoint: "http://otel-collector:4318"
+#   headers:
+#     Authorization: "Bearer ${OTLP_TOKEN}"
+#   service_name: "message-delivery-service"
+#   sample_ratio: 0.25
 
-debug: false
+# Readiness probes behind /health/ready (SMTP connect + AUTH, SMS API).
+health:
+  interval: "30s" # How long probe results are reused
+  timeout: "5s"   # Per probe
 
 # Service Authentication (Request Signing)
 # Each service that uses this API needs a unique ID and its Ed25519 public key.
-services:
-  - id: "example-client"
-    name: "Example Service"
-    public_key: "base64_ed25519_public_key_here"
+# Keys are validated on load; a config with an invalid key is rejected.
+services: []
+#  - id: "example-client"
+#    name: "Example Service"
+#    public_key: "base64_ed25519_public_key_here"
+#    client_cert_subjects: ["CN=example-client"] # Optional mTLS alternative
+#    admin: false # Allows access to GET /admin/config
+#    quiet_hours: # Defers SMS, except class "otp", to the end of the window
+#      start: "21:00" # Recipient's local time, from "timeZone" or "country"
+#      end: "08:00"
+#      time_zone: "Europe/Stockholm" # For recipients without either, default UTC
 
 # Email SMTP accounts
 # You can define multiple accounts. The "from" address in the request selects the account.
+# Any value may reference an environment variable (${SMTP_PASSWORD}, optionally
+# with a fallback: ${SMTP_PORT:-587}) or a file ("file:/run/secrets/smtp_password").
+# An address can also be "*@example.com" for the whole domain, or be replaced
+# by address_regex; "default: true" marks the account for unmatched senders.
 email_accounts:
   - address: "support@example.com"
+    # envelope_from: "bounces@example.com" # MAIL FROM, defaults to the From address
+    # verp: true # Send from bounces+<message id local part>@example.com to match bounces to messages
     smtp:
       host: "smtp.example.com"
       port: 587
       username: "user@example.com"
       password: "password"
+      # tls: "starttls"  # implicit (default on 465), starttls, starttls_required or none
+      # ca_file: "/etc/mds/smtp-ca.crt"
+      # server_name: "smtp.example.com"
+      # auth: "PLAIN"      # PLAIN, LOGIN, CRAM-MD5, XOAUTH2 or none
+      # XOAUTH2 tokens (Microsoft 365, Google Workspace) instead of a password
+      # oauth2:
+      #   token_url: "https://login.microsoftonline.com/<tenant>/oauth2/v2.0/token"
+      #   client_id: "..."
+      #   client_secret: "${SMTP_CLIENT_SECRET}"
+      #   scope: "https://outlook.office365.com/.default"
+      #   refresh_token: "" # Set to use the refresh-token grant instead of client credentials
+      # Connections are reused between messages
+      # pool:
+      #   max_connections: 2
+      #   idle_timeout: "60s"
+    # Sign outgoing messages (RSA or Ed25519 key)
+    # dkim:
+    #   selector: "mds"
+    #   private_key: "file:/etc/mds/dkim/example.com.pem"
+    #   domain: "example.com" # Defaults to the domain of the address
 
 # SMS Providers
 sms:
   46elks:
     username: "api_user_id"
     password: "api_password"
-`
 
-func Load(path string) (*Config, error) {
-	if _, err := os.Stat(path); os.IsNotExist(err) {
-		log.Printf("Config file %s not found, creating default", path)
-		if err := os.WriteFile(path, []byte(defaultConfig), 0644); err != nil {
-			return nil, fmt.Errorf("failed to create default config: %w", err)
-		}
-	}
+# Bounce and complaint processing. Hard bounces and complaints suppress the
+# recipient; reports are read from the envelope_from mailbox or posted to
+# POST /webhooks/bounces.
+# bounces:
+#   webhook_secret: "${BOUNCE_WEBHOOK_SECRET}"
+#   pop3:
+#     host: "pop.example.com"
+#     port: 995
+#     username: "bounces@example.com"
+#     password: "${BOUNCE_PASSWORD}"
+#     tls: "implicit" # implicit (default on 995), starttls or none
+#     interval: "1m"
+
+# One-click unsubscribe links (List-Unsubscribe) for emails sent with a scope.
+# base_url must reach this service; link tokens are encrypted with the secret.
+# unsubscribe:
+#   base_url: "https://mds.example.com"
+#   secret: "${UNSUBSCRIBE_SECRET}" # At least 16 characters
+
+# Message statuses, suppressions and scheduled messages. The bolt file keeps
+# them across restarts; changes apply on restart.
+storage:
+  driver: "bolt" # bolt or memory
+  path: "data/mds.db"
+`
 
+// Read parses and validates the config at path without activating it.
+func Read(path string) (*Config, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config: %w", err)
 	}
+	return Parse(data)
+}
 
-	var cfg Config
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
+// Parse decodes a config document, resolves secret references and validates
+// the result. Unknown keys are rejected so that typos do not go unnoticed.
+func Parse(data []byte) (*Config, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
-	configMutex.Lock()
-	currentConfig = &cfg
-	configMutex.Unlock()
+	if unknown := unknownFields(data); len(unknown) > 0 {
+		return nil, &ValidationError{Problems: unknown}
+	}
+
+	var cfg Config
+	if doc.Kind != 0 {
+		if err := resolveReferences(&doc); err != nil {
+			return nil, err
+		}
+		if err := doc.Decode(&cfg); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+		}
+	}
+
+	if err := cfg.Prepare(); err != nil {
+		return nil, err
+	}
+	cfg.version.Hash = contentHash(data)
 
-	DebugLog("[DEBUG] Config Loaded - Services: %d, Email Accounts: %d", len(cfg.Services), len(cfg.EmailAccounts))
 	return &cfg, nil
 }
 
-func Get() *Config {
-	configMutex.RLock()
-	defer configMutex.RUnlock()
-	return currentConfig
-}
+// Prepare applies defaults, validates c and indexes its services. Parse does
+// this already; configs built in code must be prepared before they are used.
+func (c *Config) Prepare() error {
+	c.applyDefaults()
+	if err := c.Validate(); err != nil {
+		return err
+	}
 
-func Watch(path string) error {
-	watcher, err := fsnotify.NewWatcher()
+	// Build the registry before publishing so that a broken reload keeps
+	// serving the previous good config.
+	registry, err := NewServiceRegistry(c.Services)
 	if err != nil {
-		return err
+		return &ValidationError{Problems: splitJoined(err)}
 	}
+	c.registry = registry
+	return nil
+}
 
-	go func() {
-		defer watcher.Close()
-		for {
-			select {
-			case event, ok := <-watcher.Events:
-				if !ok {
-					return
-				}
-				if event.Op&fsnotify.Write == fsnotify.Write {
-					log.Printf("Config file modified: %s, reloading...", event.Name)
-					if _, err := Load(path); err != nil {
-						log.Printf("Error reloading config: %v", err)
-					} else {
-						DebugLog("[DEBUG] Config successfully reloaded")
-					}
-				}
-			case err, ok := <-watcher.Errors:
-				if !ok {
-					return
-				}
-				log.Printf("Watcher error: %v", err)
-			}
-		}
-	}()
+// Redacted renders the config as YAML with every Secret masked, suitable for
+// logs and diagnostics.
+func (c *Config) Redacted() ([]byte, error) {
+	return yaml.Marshal(c)
+}
 
-	return watcher.Add(path)
+// Registry returns the services indexed at load time.
+func (c *Config) Registry() *ServiceRegistry {
+	return c.registry
 }
 
-func DebugLog(format string, v ...interface{}) {
-	configMutex.RLock()
-	defer configMutex.RUnlock()
-	if currentConfig != nil && currentConfig.Debug {
-		log.Printf(format, v...)
+// MaxBodyBytes returns the request body limit for the given route path.
+func (c *Config) MaxBodyBytes(path string) int64 {
+	if limit, ok := c.Server.RouteBodyLimits[path]; ok && limit > 0 {
+		return limit
+	}
+	if c.Server.MaxBodyBytes > 0 {
+		return c.Server.MaxBodyBytes
 	}
+	return DefaultMaxBodyBytes
 }
@@ -0,0 +1,240 @@
+package config
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeConfig(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+}
+
+func newPublicKey(t *testing.T) string {
+	t.Helper()
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	return base64.StdEncoding.EncodeToString(pub)
+}
+
+func TestLoad_BuildsServiceRegistry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	key := newPublicKey(t)
+	writeConfig(t, path, "services:\n"+
+		"  - id: \"a\"\n"+
+		"    public_key: \""+key+"\"\n"+
+		"  - id: \"b\"\n"+
+		"    public_key: \""+newPublicKey(t)+"\"\n")
+
+	cfg, err := NewStore(path).Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if n := cfg.Registry().Len(); n != 2 {
+		t.Fatalf("Expected 2 services, got %d", n)
+	}
+	svc, ok := cfg.Registry().Lookup("a")
+	if !ok {
+		t.Fatal("Service a not found")
+	}
+	if base64.StdEncoding.EncodeToString(svc.Key) != key {
+		t.Error("Service a key was not parsed correctly")
+	}
+	if _, ok := cfg.Registry().Lookup("missing"); ok {
+		t.Error("Lookup of unknown ID succeeded")
+	}
+}
+
+func TestLoad_RejectsInvalidServices(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{
+			name:    "invalid key",
+			yaml:    "services:\n  - id: \"a\"\n    public_key: \"not-a-key\"\n",
+			wantErr: "invalid public_key",
+		},
+		{
+			name: "duplicate id",
+			yaml: "services:\n" +
+				"  - id: \"a\"\n    public_key: \"" + newPublicKey(t) + "\"\n" +
+				"  - id: \"a\"\n    public_key: \"" + newPublicKey(t) + "\"\n",
+			wantErr: "duplicate id",
+		},
+		{
+			name:    "missing id",
+			yaml:    "services:\n  - public_key: \"" + newPublicKey(t) + "\"\n",
+			wantErr: "id is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			writeConfig(t, path, tt.yaml)
+
+			_, err := NewStore(path).Load()
+			if err == nil {
+				t.Fatal("Expected Load to fail")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestLoad_BadReloadKeepsPreviousConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, "services:\n  - id: \"good\"\n    public_key: \""+newPublicKey(t)+"\"\n")
+
+	store := NewStore(path)
+	good, err := store.Load()
+	if err != nil {
+		t.Fatalf("Initial load failed: %v", err)
+	}
+
+	writeConfig(t, path, "services:\n  - id: \"bad\"\n    public_key: \"garbage\"\n")
+	if _, err := store.Load(); err == nil {
+		t.Fatal("Expected reload with a bad key to fail")
+	}
+
+	if store.Get() != good {
+		t.Fatal("Failed reload replaced the active config")
+	}
+	if _, ok := store.Get().Registry().Lookup("good"); !ok {
+		t.Error("Previous service is no longer registered")
+	}
+}
+
+func TestLoad_ResolvesSecretReferences(t *testing.T) {
+	dir := t.TempDir()
+	secretPath := filepath.Join(dir, "smtp_password")
+	writeConfig(t, secretPath, "from-file\n")
+
+	t.Setenv("MDS_TEST_SMTP_HOST", "smtp.internal")
+	t.Setenv("MDS_TEST_SMTP_PORT", "2525")
+	t.Setenv("MDS_TEST_ELKS_PASSWORD", "from-env")
+
+	path := filepath.Join(dir, "config.yaml")
+	writeConfig(t, path, "email_accounts:\n"+
+		"  - address: \"a@example.com\"\n"+
+		"    smtp:\n"+
+		"      host: \"${MDS_TEST_SMTP_HOST}\"\n"+
+		"      port: ${MDS_TEST_SMTP_PORT}\n"+
+		"      username: \"${MDS_TEST_UNSET:-fallback}\"\n"+
+		"      password: \"file:"+secretPath+"\"\n"+
+		"sms:\n"+
+		"  46elks:\n"+
+		"    username: \"elks\"\n"+
+		"    password: \"${MDS_TEST_ELKS_PASSWORD}\"\n")
+
+	cfg, err := NewStore(path).Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	smtp := cfg.EmailAccounts[0].SMTP
+	if smtp.Host != "smtp.internal" || smtp.Port != 2525 || smtp.Username != "fallback" {
+		t.Errorf("Unexpected SMTP settings: host=%q port=%d username=%q", smtp.Host, smtp.Port, smtp.Username)
+	}
+	if smtp.Password.Value() != "from-file" {
+		t.Errorf("Expected password from file, got %q", smtp.Password.Value())
+	}
+	if cfg.Sms.FortySixElks.Password.Value() != "from-env" {
+		t.Errorf("Expected 46elks password from env, got %q", cfg.Sms.FortySixElks.Password.Value())
+	}
+
+	dump, err := cfg.Redacted()
+	if err != nil {
+		t.Fatalf("Redacted failed: %v", err)
+	}
+	for _, secret := range []string{"from-file", "from-env"} {
+		if strings.Contains(string(dump), secret) {
+			t.Errorf("Config dump leaks secret %q:\n%s", secret, dump)
+		}
+		if strings.Contains(fmt.Sprintf("%v %+v", cfg.EmailAccounts, cfg.Sms), secret) {
+			t.Errorf("Formatted config leaks secret %q", secret)
+		}
+	}
+}
+
+func TestLoad_UnresolvedReferenceFails(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, "sms:\n  46elks:\n    password: \"${MDS_TEST_DEFINITELY_UNSET}\"\n")
+
+	_, err := NewStore(path).Load()
+	if err == nil {
+		t.Fatal("Expected Load to fail for an unset variable")
+	}
+	if !strings.Contains(err.Error(), "sms.46elks.password") || !strings.Contains(err.Error(), "MDS_TEST_DEFINITELY_UNSET") {
+		t.Errorf("Error should name the field and variable, got: %v", err)
+	}
+}
+
+func TestStore_NotifiesSubscribers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, accountConfig("first@example.com"))
+	store := NewStore(path)
+
+	var seen, removed []string
+	store.Subscribe(func(cfg *Config) {
+		seen = append(seen, cfg.EmailAccounts[0].Address)
+	})
+	unsubscribe := store.Subscribe(func(cfg *Config) {
+		removed = append(removed, cfg.EmailAccounts[0].Address)
+	})
+
+	if _, err := store.Load(); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	unsubscribe()
+	writeConfig(t, path, "services:\n  - id: \"broken\"\n    public_key: \"nope\"\n")
+	store.Load()
+	writeConfig(t, path, accountConfig("second@example.com"))
+	if _, err := store.Load(); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+
+	if strings.Join(seen, ",") != "first@example.com,second@example.com" {
+		t.Errorf("Subscribers should only see successful loads, got %v", seen)
+	}
+	if len(removed) != 1 {
+		t.Errorf("Unsubscribed callback still called: %v", removed)
+	}
+}
+
+func TestStore_IndependentInstances(t *testing.T) {
+	dir := t.TempDir()
+	a, b := filepath.Join(dir, "a.yaml"), filepath.Join(dir, "b.yaml")
+	writeConfig(t, a, accountConfig("a@example.com")+"debug: true\n")
+	writeConfig(t, b, accountConfig("b@example.com"))
+
+	storeA, storeB := NewStore(a), NewStore(b)
+	if _, err := storeA.Load(); err != nil {
+		t.Fatalf("Load a failed: %v", err)
+	}
+	if _, err := storeB.Load(); err != nil {
+		t.Fatalf("Load b failed: %v", err)
+	}
+
+	if storeA.Get().EmailAccounts[0].Address != "a@example.com" || storeB.Get().EmailAccounts[0].Address != "b@example.com" {
+		t.Error("Stores share state")
+	}
+	if !storeA.Get().Debug || storeB.Get().Debug {
+		t.Error("Debug setting leaked between stores")
+	}
+}
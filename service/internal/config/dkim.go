@@ -0,0 +1,62 @@
+package config
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+// DefaultDKIMHeaders are signed when email_accounts[].dkim.headers is not
+// set, following RFC 6376 section 5.4.1.
+var DefaultDKIMHeaders = []string{
+	"From", "Reply-To", "Subject", "Date", "To", "Cc", "Message-ID",
+	"MIME-Version", "Content-Type", "Content-Transfer-Encoding",
+	"List-Unsubscribe", "List-Unsubscribe-Post",
+}
+
+// DKIMConfig signs the messages of an email account. Domain defaults to the
+// domain of the account's address.
+type DKIMConfig struct {
+	Domain   string `yaml:"domain"`
+	Selector string `yaml:"selector"`
+
+	// PrivateKey is a PEM encoded RSA (PKCS#1 or PKCS#8) or Ed25519
+	// (PKCS#8) key, typically given as "file:/path/to/key.pem".
+	PrivateKey Secret `yaml:"private_key"`
+
+	Headers []string `yaml:"headers"`
+}
+
+// Enabled reports whether any DKIM setting is present.
+func (c DKIMConfig) Enabled() bool {
+	return c.Selector != "" || c.PrivateKey != "" || c.Domain != "" || len(c.Headers) > 0
+}
+
+// Signer parses the private key.
+func (c DKIMConfig) Signer() (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(c.PrivateKey.Value()))
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		switch key := key.(type) {
+		case *rsa.PrivateKey:
+			return key, nil
+		case ed25519.PrivateKey:
+			return key, nil
+		}
+		return nil, fmt.Errorf("unsupported key type %T (expected RSA or Ed25519)", key)
+	}
+	return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+}
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+)
+
+func TestDKIMConfig_Signer(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate RSA key: %v", err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate Ed25519 key: %v", err)
+	}
+	pkcs8 := func(key any) []byte {
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			t.Fatalf("Failed to marshal key: %v", err)
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	}
+
+	tests := map[string][]byte{
+		"rsa pkcs1": pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}),
+		"rsa pkcs8": pkcs8(rsaKey),
+		"ed25519":   pkcs8(edKey),
+	}
+	for name, key := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := (DKIMConfig{PrivateKey: Secret(key)}).Signer(); err != nil {
+				t.Errorf("Failed to parse key: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// ParsePublicKey accepts an Ed25519 public key as OpenSSH authorized key,
+// PEM-encoded PKIX or raw 32 bytes, each optionally Base64-wrapped.
+func ParsePublicKey(keyStr string) (ed25519.PublicKey, error) {
+	var keyBytes []byte
+	var err error
+
+	// Try Base64 decode first
+	keyBytes, err = base64.StdEncoding.DecodeString(keyStr)
+	if err != nil {
+		keyBytes = []byte(keyStr)
+	}
+
+	// 1. Try OpenSSH format
+	if bytes.Contains(keyBytes, []byte("ssh-ed25519")) || bytes.Contains(keyBytes, []byte("BEGIN")) {
+		pub, _, _, _, err := ssh.ParseAuthorizedKey(keyBytes)
+		if err == nil {
+			if edKey, ok := pub.(ssh.CryptoPublicKey); ok {
+				if pk, ok := edKey.CryptoPublicKey().(ed25519.PublicKey); ok {
+					return pk, nil
+				}
+			}
+		}
+
+		// Try as raw SSH body (sometimes folks copy just the base64 part of the pubkey)
+		// But usually it's easier to try parsing as a generic PEM PKIX public key
+		block, _ := pem.Decode(keyBytes)
+		if block != nil {
+			pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+			if err == nil {
+				if pk, ok := pub.(ed25519.PublicKey); ok {
+					return pk, nil
+				}
+			}
+		}
+	}
+
+	// 2. Handle raw bytes
+	if len(keyBytes) == ed25519.PublicKeySize {
+		return ed25519.PublicKey(keyBytes), nil
+	}
+
+	return nil, fmt.Errorf("unsupported public key format or invalid size (%d bytes)", len(keyBytes))
+}
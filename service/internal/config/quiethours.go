@@ -0,0 +1,64 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// QuietHoursConfig defers SMS that would reach a recipient between Start and
+// End of the recipient's local day, e.g. from 21:00 to 08:00, to the end of
+// the window. A window with Start after End spans midnight.
+type QuietHoursConfig struct {
+	Start string `yaml:"start"` // "HH:MM"
+	End   string `yaml:"end"`   // "HH:MM"
+
+	// TimeZone is used for recipients whose time zone is neither given nor
+	// known from their country. The default is UTC.
+	TimeZone string `yaml:"time_zone"`
+}
+
+// Enabled reports whether quiet hours are configured.
+func (c QuietHoursConfig) Enabled() bool {
+	return c.Start != "" || c.End != ""
+}
+
+// Window returns Start and End as offsets from midnight.
+func (c QuietHoursConfig) Window() (start, end time.Duration, err error) {
+	if start, err = clockTime(c.Start); err != nil {
+		return 0, 0, fmt.Errorf("start: %w", err)
+	}
+	if end, err = clockTime(c.End); err != nil {
+		return 0, 0, fmt.Errorf("end: %w", err)
+	}
+	return start, end, nil
+}
+
+// Location returns the time zone for recipients without one.
+func (c QuietHoursConfig) Location() (*time.Location, error) {
+	return time.LoadLocation(c.TimeZone)
+}
+
+func (c QuietHoursConfig) problems() []string {
+	if !c.Enabled() {
+		return nil
+	}
+	var problems []string
+	if start, end, err := c.Window(); err != nil {
+		problems = append(problems, err.Error())
+	} else if start == end {
+		problems = append(problems, "end: must differ from start")
+	}
+	if _, err := c.Location(); err != nil {
+		problems = append(problems, fmt.Sprintf("time_zone: unknown time zone %q", c.TimeZone))
+	}
+	return problems
+}
+
+// clockTime parses an "HH:MM" time of day.
+func clockTime(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a time of day (expected HH:MM)", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"crypto/ed25519"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+)
+
+// Service is a registered API client with its pre-parsed verification key.
+// Key is nil for services that only authenticate with client certificates.
+type Service struct {
+	ID         string
+	Name       string
+	Key        ed25519.PublicKey
+	Admin      bool
+	QuietHours QuietHoursConfig
+}
+
+// ServiceRegistry indexes services by client ID. It is built once per
+// config load and never mutated afterwards, so it is safe for concurrent use.
+type ServiceRegistry struct {
+	byID      map[string]*Service
+	bySubject map[string]*Service
+}
+
+// NewServiceRegistry parses every service key and indexes the services by ID.
+// All problems are reported together so a broken config can be fixed in one go.
+func NewServiceRegistry(services []ServiceConfig) (*ServiceRegistry, error) {
+	reg := &ServiceRegistry{
+		byID:      make(map[string]*Service, len(services)),
+		bySubject: make(map[string]*Service),
+	}
+
+	var errs []error
+	for i, s := range services {
+		if s.ID == "" {
+			errs = append(errs, fmt.Errorf("services[%d]: id is required", i))
+			continue
+		}
+		if _, dup := reg.byID[s.ID]; dup {
+			errs = append(errs, fmt.Errorf("services[%d]: duplicate id %q", i, s.ID))
+			continue
+		}
+
+		if s.PublicKey == "" && len(s.ClientCertSubjects) == 0 {
+			errs = append(errs, fmt.Errorf("services[%d] (%s): public_key or client_cert_subjects is required", i, s.ID))
+			continue
+		}
+
+		svc := &Service{ID: s.ID, Name: s.Name, Admin: s.Admin, QuietHours: s.QuietHours}
+		if s.PublicKey != "" {
+			key, err := ParsePublicKey(s.PublicKey)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("services[%d] (%s): invalid public_key: %w", i, s.ID, err))
+				continue
+			}
+			svc.Key = key
+		}
+
+		for _, subject := range s.ClientCertSubjects {
+			if other, dup := reg.bySubject[subject]; dup {
+				errs = append(errs, fmt.Errorf("services[%d] (%s): client_cert_subject %q already used by %s", i, s.ID, subject, other.ID))
+				continue
+			}
+			reg.bySubject[subject] = svc
+		}
+
+		reg.byID[s.ID] = svc
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return reg, nil
+}
+
+// Lookup returns the service registered under id.
+func (r *ServiceRegistry) Lookup(id string) (*Service, bool) {
+	if r == nil {
+		return nil, false
+	}
+	s, ok := r.byID[id]
+	return s, ok
+}
+
+// LookupSubject returns the service mapped to a client certificate subject,
+// matching the full DN first and the common name second.
+func (r *ServiceRegistry) LookupSubject(subject pkix.Name) (*Service, bool) {
+	if r == nil {
+		return nil, false
+	}
+	if s, ok := r.bySubject[subject.String()]; ok {
+		return s, true
+	}
+	if subject.CommonName != "" {
+		if s, ok := r.bySubject[subject.CommonName]; ok {
+			return s, true
+		}
+	}
+	return nil, false
+}
+
+// Len returns the number of registered services.
+func (r *ServiceRegistry) Len() int {
+	if r == nil {
+		return 0
+	}
+	return len(r.byID)
+}
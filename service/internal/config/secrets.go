@@ -0,0 +1,125 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const redacted = "[REDACTED]"
+
+// Secret is a config value that must never appear in logs or config dumps.
+// Formatting it with fmt or marshalling it yields "[REDACTED]"; use Value to
+// obtain the actual secret.
+type Secret string
+
+// Value returns the secret in clear text.
+func (s Secret) Value() string {
+	return string(s)
+}
+
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return redacted
+}
+
+func (s Secret) GoString() string {
+	return `config.Secret("` + s.String() + `")`
+}
+
+func (s Secret) MarshalYAML() (interface{}, error) {
+	return s.String(), nil
+}
+
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + s.String() + `"`), nil
+}
+
+// envRef matches ${NAME} and ${NAME:-default}.
+var envRef = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(?::-([^}]*))?\}`)
+
+// filePrefix marks a value that should be read from a file, typically a
+// Docker or Kubernetes secret mounted under /run/secrets.
+const filePrefix = "file:"
+
+// resolveReferences expands ${ENV_VAR} references and file: values in every
+// scalar of the document, recording the path of each problem.
+func resolveReferences(node *yaml.Node) error {
+	var errs []string
+	walkScalars(node, "", func(n *yaml.Node, path string) {
+		if err := resolveScalar(n); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", path, err))
+		}
+	})
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to resolve config references:\n  %s", strings.Join(errs, "\n  "))
+	}
+	return nil
+}
+
+func resolveScalar(n *yaml.Node) error {
+	value := n.Value
+	if !strings.Contains(value, "${") && !strings.HasPrefix(value, filePrefix) {
+		return nil
+	}
+
+	var missing []string
+	value = envRef.ReplaceAllStringFunc(value, func(ref string) string {
+		m := envRef.FindStringSubmatch(ref)
+		if v, ok := os.LookupEnv(m[1]); ok {
+			return v
+		}
+		if strings.Contains(ref, ":-") {
+			return m[2]
+		}
+		missing = append(missing, m[1])
+		return ""
+	})
+	if len(missing) > 0 {
+		return fmt.Errorf("environment variable %s is not set", strings.Join(missing, ", "))
+	}
+
+	if path, ok := strings.CutPrefix(value, filePrefix); ok {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read secret file: %w", err)
+		}
+		value = strings.TrimRight(string(data), "\r\n")
+	}
+
+	n.Value = value
+	// Let unquoted values such as `port: ${SMTP_PORT}` resolve to their
+	// natural type again instead of staying strings.
+	if n.Style == 0 {
+		n.Tag = ""
+	}
+	return nil
+}
+
+func walkScalars(n *yaml.Node, path string, fn func(*yaml.Node, string)) {
+	switch n.Kind {
+	case yaml.DocumentNode:
+		for _, c := range n.Content {
+			walkScalars(c, path, fn)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key := n.Content[i].Value
+			if path != "" {
+				key = path + "." + key
+			}
+			walkScalars(n.Content[i+1], key, fn)
+		}
+	case yaml.SequenceNode:
+		for i, c := range n.Content {
+			walkScalars(c, fmt.Sprintf("%s[%d]", path, i), fn)
+		}
+	case yaml.ScalarNode:
+		fn(n, path)
+	}
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// maxReloadEvents bounds the reload history kept for the admin endpoint.
+const maxReloadEvents = 20
+
+// Version identifies a loaded config by the SHA-256 of its file contents, so
+// instances running the same file report the same hash.
+type Version struct {
+	Hash     string
+	LoadedAt time.Time
+}
+
+// ReloadEvent records one load attempt. Hash is empty when the load failed.
+type ReloadEvent struct {
+	Time  time.Time
+	Hash  string
+	Error string
+}
+
+// Status describes the active config and the outcome of recent loads.
+type Status struct {
+	Path    string
+	Version Version
+
+	// Reloads and FailedReloads count load attempts after the initial one.
+	Reloads       int
+	FailedReloads int
+
+	LastError   string
+	LastErrorAt time.Time
+
+	// Events lists the most recent load attempts, oldest first.
+	Events []ReloadEvent
+}
+
+func contentHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Version returns the content hash and activation time of c.
+func (c *Config) Version() Version {
+	return c.version
+}
+
+// RedactedMap returns the config with secrets masked as a generic map, for
+// rendering as JSON.
+func (c *Config) RedactedMap() (map[string]interface{}, error) {
+	data, err := c.Redacted()
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestLoad_RecordsStatus(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, accountConfig("v1@example.com"))
+
+	store := NewStore(path)
+	first, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	before := store.Status()
+	if before.Version != first.Version() || len(first.Version().Hash) != 64 {
+		t.Fatalf("Status does not report the loaded version: %+v", before.Version)
+	}
+
+	// Reloading identical content yields the same hash.
+	if again, err := store.Load(); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	} else if again.Version().Hash != first.Version().Hash {
+		t.Error("Hash changed for identical content")
+	}
+
+	writeConfig(t, path, "server:\n  port: 0x\n")
+	if _, err := store.Load(); err == nil {
+		t.Fatal("Expected bad reload to fail")
+	}
+
+	after := store.Status()
+	if after.Reloads != before.Reloads+1 || after.FailedReloads != before.FailedReloads+1 {
+		t.Errorf("Unexpected counters: reloads %d -> %d, failed %d -> %d",
+			before.Reloads, after.Reloads, before.FailedReloads, after.FailedReloads)
+	}
+	if after.Version.Hash != first.Version().Hash {
+		t.Error("Failed reload changed the reported version")
+	}
+	if after.LastError == "" || after.LastErrorAt.IsZero() {
+		t.Error("Failed reload did not record the last error")
+	}
+
+	last := after.Events[len(after.Events)-1]
+	if last.Error == "" || last.Hash != "" {
+		t.Errorf("Expected a failed event, got %+v", last)
+	}
+	if len(after.Events) > maxReloadEvents {
+		t.Errorf("History exceeds %d events", maxReloadEvents)
+	}
+}
+
+func TestRedactedMap_MasksSecrets(t *testing.T) {
+	cfg, err := Parse([]byte(accountConfig("a@example.com") +
+		"      username: \"user\"\n      password: \"hunter2\"\n"))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	m, err := cfg.RedactedMap()
+	if err != nil {
+		t.Fatalf("RedactedMap failed: %v", err)
+	}
+	accounts := m["email_accounts"].([]interface{})
+	smtp := accounts[0].(map[string]interface{})["smtp"].(map[string]interface{})
+	if smtp["password"] != "[REDACTED]" {
+		t.Errorf("Expected password to be redacted, got %v", smtp["password"])
+	}
+}
@@ -0,0 +1,35 @@
+package config
+
+import "fmt"
+
+// StorageConfig selects where service state is kept: message statuses, the
+// suppression list and scheduled messages. Changes apply on restart.
+type StorageConfig struct {
+	Driver string `yaml:"driver"` // bolt (default) or memory
+
+	// Path is the database file of the bolt driver (default
+	// DefaultStoragePath), relative to the working directory.
+	Path string `yaml:"path"`
+}
+
+// Storage drivers.
+const (
+	StorageBolt   = "bolt"   // a file that survives restarts
+	StorageMemory = "memory" // lost on restart, e.g. for tests
+)
+
+// DefaultStoragePath applies when storage.path is not set.
+const DefaultStoragePath = "data/mds.db"
+
+func (c StorageConfig) problems() []string {
+	switch c.Driver {
+	case StorageBolt:
+	case StorageMemory:
+		if c.Path != "" {
+			return []string{"path: is only used by the bolt driver"}
+		}
+	default:
+		return []string{fmt.Sprintf("driver: unknown value %q", c.Driver)}
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// Store holds the active config of one service instance. Components receive
+// the store instead of reading package state, so several instances, or tests
+// with different configs, can run side by side in one process.
+type Store struct {
+	path   string
+	logger *slog.Logger
+
+	mu      sync.RWMutex
+	current *Config
+
+	subsMu  sync.Mutex
+	subs    map[int]func(*Config)
+	nextSub int
+
+	statusMu sync.Mutex
+	status   Status
+	loads    int
+}
+
+// NewStore returns an empty store backed by the config file at path. Call
+// Load to read it, or Set to publish a config built in code.
+func NewStore(path string) *Store {
+	return &Store{
+		path:   path,
+		logger: slog.Default(),
+		subs:   make(map[int]func(*Config)),
+	}
+}
+
+// SetLogger replaces the logger, which defaults to slog.Default. The logger
+// usually depends on the config, so it is set after the first load and before
+// Watch.
+func (s *Store) SetLogger(l *slog.Logger) {
+	s.logger = l
+}
+
+// NewStaticStore returns a store serving cfg, for configs that do not come
+// from a file.
+func NewStaticStore(cfg *Config) *Store {
+	s := NewStore("")
+	s.Set(cfg)
+	return s
+}
+
+// Path returns the config file the store loads from.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Load reads and validates the config file and makes it the active config.
+// If the file does not exist a default one is created first. An invalid
+// config is rejected and the previously active config stays in place.
+func (s *Store) Load() (*Config, error) {
+	if _, err := os.Stat(s.path); os.IsNotExist(err) {
+		s.logger.Warn("Config file not found, creating default", "path", s.path)
+		if err := os.WriteFile(s.path, []byte(defaultConfig), 0644); err != nil {
+			return nil, fmt.Errorf("failed to create default config: %w", err)
+		}
+	}
+
+	cfg, err := Read(s.path)
+	if err != nil {
+		s.record(nil, err)
+		return nil, err
+	}
+
+	s.Set(cfg)
+	s.record(cfg, nil)
+	s.logger.Info("Config loaded", "path", s.path, "sha256", cfg.version.Hash,
+		"services", cfg.registry.Len(), "email_accounts", len(cfg.EmailAccounts))
+	return cfg, nil
+}
+
+// Set makes cfg the active config and notifies subscribers. cfg must come
+// from Parse or Read, or have been prepared with Config.Prepare.
+func (s *Store) Set(cfg *Config) {
+	if cfg.version.LoadedAt.IsZero() {
+		cfg.version.LoadedAt = time.Now()
+	}
+
+	s.mu.Lock()
+	s.current = cfg
+	s.mu.Unlock()
+
+	s.subsMu.Lock()
+	subs := make([]func(*Config), 0, len(s.subs))
+	for id := 0; id < s.nextSub; id++ {
+		if fn, ok := s.subs[id]; ok {
+			subs = append(subs, fn)
+		}
+	}
+	s.subsMu.Unlock()
+
+	for _, fn := range subs {
+		fn(cfg)
+	}
+}
+
+// Get returns the active config, or nil before the first load.
+func (s *Store) Get() *Config {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.current
+}
+
+// Subscribe registers fn to be called, in registration order, with every
+// config that becomes active. The returned function removes the subscription.
+func (s *Store) Subscribe(fn func(*Config)) (unsubscribe func()) {
+	s.subsMu.Lock()
+	defer s.subsMu.Unlock()
+
+	id := s.nextSub
+	s.nextSub++
+	s.subs[id] = fn
+
+	return func() {
+		s.subsMu.Lock()
+		defer s.subsMu.Unlock()
+		delete(s.subs, id)
+	}
+}
+
+// Status returns a snapshot of the load history.
+func (s *Store) Status() Status {
+	s.statusMu.Lock()
+	defer s.statusMu.Unlock()
+	status := s.status
+	status.Events = append([]ReloadEvent(nil), s.status.Events...)
+	return status
+}
+
+func (s *Store) record(cfg *Config, err error) {
+	s.statusMu.Lock()
+	defer s.statusMu.Unlock()
+
+	now := time.Now()
+	event := ReloadEvent{Time: now}
+	if s.loads > 0 {
+		if err != nil {
+			s.status.FailedReloads++
+		} else {
+			s.status.Reloads++
+		}
+	}
+	s.loads++
+
+	s.status.Path = s.path
+	if err != nil {
+		event.Error = err.Error()
+		s.status.LastError = event.Error
+		s.status.LastErrorAt = now
+	} else {
+		event.Hash = cfg.version.Hash
+		s.status.Version = cfg.version
+	}
+
+	s.status.Events = append(s.status.Events, event)
+	if len(s.status.Events) > maxReloadEvents {
+		s.status.Events = s.status.Events[len(s.status.Events)-maxReloadEvents:]
+	}
+}
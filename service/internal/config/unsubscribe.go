@@ -0,0 +1,40 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// UnsubscribeConfig adds one-click unsubscribe links (RFC 8058) to emails
+// sent with a suppression scope. Links point to BaseURL, which must reach
+// this service; their tokens are encrypted with a key derived from Secret.
+type UnsubscribeConfig struct {
+	BaseURL string `yaml:"base_url"` // e.g. "https://mds.example.com"
+	Secret  Secret `yaml:"secret"`
+}
+
+// Enabled reports whether unsubscribe links are configured.
+func (c UnsubscribeConfig) Enabled() bool {
+	return c.BaseURL != ""
+}
+
+// minUnsubscribeSecret is the shortest secret accepted for links.
+const minUnsubscribeSecret = 16
+
+func (c UnsubscribeConfig) problems() []string {
+	if !c.Enabled() {
+		if c.Secret != "" {
+			return []string{"base_url: is required when a secret is set"}
+		}
+		return nil
+	}
+
+	var problems []string
+	if u, err := url.Parse(c.BaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		problems = append(problems, fmt.Sprintf("base_url: %q is not an http(s) URL", c.BaseURL))
+	}
+	if len(c.Secret.Value()) < minUnsubscribeSecret {
+		problems = append(problems, fmt.Sprintf("secret: must be at least %d characters", minUnsubscribeSecret))
+	}
+	return problems
+}
@@ -0,0 +1,345 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultPort is used when server.port is not set.
+const DefaultPort = 3000
+
+// maxVERPLocalPart is the longest envelope_from local part that VERP can
+// extend with "+" and the 37-character local part of a message ID generated
+// by the service within the 64 characters RFC 5321 allows.
+const maxVERPLocalPart = 64 - 1 - 37
+
+// ValidationError lists every semantic problem found in a config, each
+// prefixed with the path of the offending field.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid config:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+func (c *Config) applyDefaults() {
+	if c.Server.Port == 0 {
+		c.Server.Port = DefaultPort
+	}
+	if c.Health.Interval == 0 {
+		c.Health.Interval = DefaultHealthInterval
+	}
+	if c.Health.Timeout == 0 {
+		c.Health.Timeout = DefaultHealthTimeout
+	}
+	for i := range c.EmailAccounts {
+		pool := &c.EmailAccounts[i].SMTP.Pool
+		if pool.MaxConnections == 0 {
+			pool.MaxConnections = DefaultSMTPMaxConnections
+		}
+		if pool.IdleTimeout == 0 {
+			pool.IdleTimeout = DefaultSMTPIdleTimeout
+		}
+		if dkim := &c.EmailAccounts[i].DKIM; dkim.Enabled() {
+			if dkim.Domain == "" {
+				dkim.Domain = c.EmailAccounts[i].Domain()
+			}
+			if dkim.Headers == nil {
+				dkim.Headers = slices.Clone(DefaultDKIMHeaders)
+			}
+		}
+		oauth := &c.EmailAccounts[i].SMTP.OAuth2
+		if oauth.Enabled() && oauth.RefreshBefore == 0 {
+			oauth.RefreshBefore = DefaultOAuth2RefreshBefore
+		}
+	}
+	if pop3 := &c.Bounces.POP3; pop3.Enabled() && pop3.Interval == 0 {
+		pop3.Interval = DefaultPOP3Interval
+	}
+	if c.Storage.Driver == "" {
+		c.Storage.Driver = StorageBolt
+	}
+	if c.Storage.Driver == StorageBolt && c.Storage.Path == "" {
+		c.Storage.Path = DefaultStoragePath
+	}
+}
+
+// Validate checks the config for semantic problems that decoding alone does
+// not catch. All problems are reported at once.
+func (c *Config) Validate() error {
+	var problems []string
+	add := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	// Server
+	if c.Server.Port < 1 || c.Server.Port > 65535 {
+		add("server.port: must be between 1 and 65535, got %d", c.Server.Port)
+	}
+	if c.Server.MaxBodyBytes < 0 {
+		add("server.max_body_bytes: must not be negative")
+	}
+	for route, limit := range c.Server.RouteBodyLimits {
+		if !strings.HasPrefix(route, "/") {
+			add("server.route_body_limits[%s]: route must start with /", route)
+		}
+		if limit <= 0 {
+			add("server.route_body_limits[%s]: must be positive", route)
+		}
+	}
+	for _, p := range c.Server.TLS.problems() {
+		add("server.tls.%s", p)
+	}
+
+	// Logging
+	switch strings.ToLower(c.Logging.Level) {
+	case "", "debug", "info", "warn", "error":
+	default:
+		add("logging.level: unknown value %q (expected debug, info, warn or error)", c.Logging.Level)
+	}
+	switch strings.ToLower(c.Logging.Format) {
+	case "", "text", "json":
+	default:
+		add("logging.format: unknown value %q (expected text or json)", c.Logging.Format)
+	}
+
+	// Tracing
+	if c.Tracing.Endpoint != "" {
+		if u, err := url.Parse(c.Tracing.Endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			add("tracing.endpoint: %q is not an http(s) URL", c.Tracing.Endpoint)
+		}
+	}
+	if r := c.Tracing.SampleRatio; r != nil && (*r < 0 || *r > 1) {
+		add("tracing.sample_ratio: must be between 0 and 1, got %g", *r)
+	}
+
+	// Health
+	if c.Health.Interval < 0 {
+		add("health.interval: must not be negative")
+	}
+	if c.Health.Timeout < 0 {
+		add("health.timeout: must not be negative")
+	}
+
+	// Services
+	for i, svc := range c.Services {
+		for _, p := range svc.QuietHours.problems() {
+			add("services[%d].quiet_hours.%s", i, p)
+		}
+	}
+
+	// Email accounts
+	seen := make(map[string]int)
+	defaultAccount := -1
+	for i, acc := range c.EmailAccounts {
+		field := fmt.Sprintf("email_accounts[%d]", i)
+		switch {
+		case acc.Address != "" && acc.AddressRegex != "":
+			add("%s.address_regex: cannot be combined with address", field)
+		case acc.Address == "" && acc.AddressRegex == "" && !acc.Default:
+			add("%s.address: is required unless address_regex or default is set", field)
+		case acc.Address != "":
+			if !validAccountAddress(acc.Address) {
+				add("%s.address: %q is not a valid email address or *@domain", field, acc.Address)
+			} else if prev, dup := seen[strings.ToLower(acc.Address)]; dup {
+				add("%s.address: duplicate of email_accounts[%d]", field, prev)
+			} else {
+				seen[strings.ToLower(acc.Address)] = i
+			}
+		case acc.AddressRegex != "":
+			if _, err := regexp.Compile(acc.AddressRegex); err != nil {
+				add("%s.address_regex: %v", field, err)
+			} else if prev, dup := seen[acc.Name()]; dup {
+				add("%s.address_regex: duplicate of email_accounts[%d]", field, prev)
+			} else {
+				seen[acc.Name()] = i
+			}
+		}
+		if acc.Default {
+			if defaultAccount >= 0 {
+				add("%s.default: email_accounts[%d] is already the default", field, defaultAccount)
+			} else {
+				defaultAccount = i
+			}
+		}
+		if acc.EnvelopeFrom != "" {
+			if addr, err := mail.ParseAddress(acc.EnvelopeFrom); err != nil {
+				add("%s.envelope_from: %q is not a valid email address", field, acc.EnvelopeFrom)
+			} else if local := addr.Address[:strings.LastIndex(addr.Address, "@")]; acc.VERP && len(local) > maxVERPLocalPart {
+				add("%s.envelope_from: local part must be at most %d characters for verp", field, maxVERPLocalPart)
+			}
+		} else if acc.VERP {
+			add("%s.envelope_from: is required for verp", field)
+		}
+
+		if acc.SMTP.Host == "" {
+			add("%s.smtp.host: is required", field)
+		}
+		if acc.SMTP.Port < 1 || acc.SMTP.Port > 65535 {
+			add("%s.smtp.port: must be between 1 and 65535, got %d", field, acc.SMTP.Port)
+		}
+		if acc.SMTP.Password != "" && acc.SMTP.Username == "" {
+			add("%s.smtp.username: is required when a password is set", field)
+		}
+		switch acc.SMTP.TLSMode() {
+		case SMTPTLSImplicit, SMTPTLSStartTLS, SMTPTLSStartTLSRequired, SMTPTLSNone:
+		default:
+			add("%s.smtp.tls: unknown value %q (expected implicit, starttls, starttls_required or none)", field, acc.SMTP.TLS)
+		}
+		switch mech := acc.SMTP.AuthMechanism(); mech {
+		case SMTPAuthNone:
+		case SMTPAuthPlain, SMTPAuthLogin, SMTPAuthCRAMMD5, SMTPAuthXOAuth2:
+			if acc.SMTP.Username == "" {
+				add("%s.smtp.username: is required for auth %s", field, mech)
+			}
+		default:
+			add("%s.smtp.auth: unknown value %q (expected PLAIN, LOGIN, CRAM-MD5, XOAUTH2 or none)", field, acc.SMTP.Auth)
+		}
+		if oauth := acc.SMTP.OAuth2; oauth.Enabled() {
+			if u, err := url.Parse(oauth.TokenURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				add("%s.smtp.oauth2.token_url: %q is not an http(s) URL", field, oauth.TokenURL)
+			}
+			if oauth.ClientID == "" {
+				add("%s.smtp.oauth2.client_id: is required", field)
+			}
+			if oauth.RefreshBefore < 0 {
+				add("%s.smtp.oauth2.refresh_before: must not be negative", field)
+			}
+			if mech := acc.SMTP.AuthMechanism(); mech != SMTPAuthXOAuth2 {
+				add("%s.smtp.auth: must be XOAUTH2 when oauth2 is configured, got %s", field, mech)
+			}
+		} else if oauth != (SMTPOAuth2Config{}) {
+			add("%s.smtp.oauth2.token_url: is required", field)
+		}
+		if dkim := acc.DKIM; dkim.Enabled() {
+			if dkim.Domain == "" {
+				add("%s.dkim.domain: is required when the account has no address", field)
+			}
+			if dkim.Selector == "" {
+				add("%s.dkim.selector: is required", field)
+			}
+			if dkim.PrivateKey == "" {
+				add("%s.dkim.private_key: is required", field)
+			} else if _, err := dkim.Signer(); err != nil {
+				add("%s.dkim.private_key: %v", field, err)
+			}
+			if !slices.ContainsFunc(dkim.Headers, func(h string) bool { return strings.EqualFold(h, "From") }) {
+				add("%s.dkim.headers: must include From", field)
+			}
+		}
+		if acc.SMTP.Pool.MaxConnections < 0 {
+			add("%s.smtp.pool.max_connections: must not be negative", field)
+		}
+		if acc.SMTP.Pool.IdleTimeout < 0 {
+			add("%s.smtp.pool.idle_timeout: must not be negative", field)
+		}
+	}
+
+	// SMS
+	elks := c.Sms.FortySixElks
+	if (elks.Username == "") != (elks.Password == "") {
+		add("sms.46elks: username and password must be set together")
+	}
+
+	// Bounces
+	for _, p := range c.Bounces.POP3.problems() {
+		add("bounces.pop3.%s", p)
+	}
+
+	// Unsubscribe
+	for _, p := range c.Unsubscribe.problems() {
+		add("unsubscribe.%s", p)
+	}
+
+	// Storage
+	for _, p := range c.Storage.problems() {
+		add("storage.%s", p)
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+func (t TLSConfig) problems() []string {
+	if !t.Enabled() {
+		if t.ClientCAFile != "" {
+			return []string{"client_ca_file: requires cert_file and key_file"}
+		}
+		return nil
+	}
+
+	var problems []string
+	if t.CertFile == "" {
+		problems = append(problems, "cert_file: is required when key_file is set")
+	}
+	if t.KeyFile == "" {
+		problems = append(problems, "key_file: is required when cert_file is set")
+	}
+	switch t.ClientAuth {
+	case "", "none", "request":
+	case "verify_if_given", "require":
+		if t.ClientCAFile == "" {
+			problems = append(problems, fmt.Sprintf("client_auth: %q requires client_ca_file", t.ClientAuth))
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("client_auth: unknown value %q (expected none, request, verify_if_given or require)", t.ClientAuth))
+	}
+	return problems
+}
+
+// unknownFields reports keys in the raw document that do not map to a config
+// field. The raw bytes are used, rather than the resolved document, so that
+// line numbers match the file on disk.
+func unknownFields(data []byte) []string {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+
+	var probe Config
+	var typeErr *yaml.TypeError
+	if err := dec.Decode(&probe); !errors.As(err, &typeErr) {
+		return nil
+	}
+
+	// Other type errors may be caused by unresolved ${VAR} references and are
+	// reported after resolution instead.
+	var unknown []string
+	for _, msg := range typeErr.Errors {
+		if strings.Contains(msg, "not found in type") {
+			unknown = append(unknown, msg)
+		}
+	}
+	return unknown
+}
+
+// splitJoined flattens an errors.Join result into one message per error.
+func splitJoined(err error) []string {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var msgs []string
+		for _, e := range joined.Unwrap() {
+			msgs = append(msgs, e.Error())
+		}
+		return msgs
+	}
+	return []string{err.Error()}
+}
+
+// validAccountAddress accepts an email address or "*@domain".
+func validAccountAddress(address string) bool {
+	if domain, ok := strings.CutPrefix(address, DomainWildcard+"@"); ok {
+		_, err := mail.ParseAddress("x@" + domain)
+		return err == nil
+	}
+	_, err := mail.ParseAddress(address)
+	return err == nil
+}
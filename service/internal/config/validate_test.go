@@ -0,0 +1,241 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParse_DefaultConfigIsValid(t *testing.T) {
+	cfg, err := Parse([]byte(defaultConfig))
+	if err != nil {
+		t.Fatalf("Default config is invalid: %v", err)
+	}
+	if cfg.Server.Port != 3000 {
+		t.Errorf("Expected default port 3000, got %d", cfg.Server.Port)
+	}
+	if cfg.Health.Interval != DefaultHealthInterval || cfg.Health.Timeout != DefaultHealthTimeout {
+		t.Errorf("Unexpected health settings: %+v", cfg.Health)
+	}
+	if cfg.Storage.Driver != StorageBolt || cfg.Storage.Path != DefaultStoragePath {
+		t.Errorf("Unexpected storage settings: %+v", cfg.Storage)
+	}
+}
+
+func TestParse_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want []string
+	}{
+		{
+			name: "unknown key",
+			yaml: "# comment\nserver:\n  hots: \"0.0.0.0\"\n",
+			want: []string{"line 3: field hots not found"},
+		},
+		{
+			name: "port out of range",
+			yaml: "server:\n  port: 70000\n",
+			want: []string{"server.port: must be between 1 and 65535"},
+		},
+		{
+			name: "smtp account problems",
+			yaml: "email_accounts:\n" +
+				"  - address: \"not-an-address\"\n" +
+				"    smtp:\n      port: 0\n" +
+				"  - address: \"a@example.com\"\n" +
+				"    smtp:\n      host: \"smtp\"\n      port: 587\n" +
+				"  - address: \"A@example.com\"\n" +
+				"    smtp:\n      host: \"smtp\"\n      port: 587\n" +
+				"      pool:\n        max_connections: -1\n",
+			want: []string{
+				"email_accounts[0].address: \"not-an-address\" is not a valid email address",
+				"email_accounts[0].smtp.host: is required",
+				"email_accounts[0].smtp.port: must be between 1 and 65535",
+				"email_accounts[2].address: duplicate of email_accounts[1]",
+				"email_accounts[2].smtp.pool.max_connections: must not be negative",
+			},
+		},
+		{
+			name: "smtp tls and auth",
+			yaml: "email_accounts:\n" +
+				"  - address: \"a@example.com\"\n" +
+				"    smtp:\n      host: \"smtp\"\n      port: 587\n      tls: \"sometimes\"\n      auth: \"GSSAPI\"\n" +
+				"  - address: \"b@example.com\"\n" +
+				"    smtp:\n      host: \"smtp\"\n      port: 587\n      auth: \"login\"\n",
+			want: []string{
+				"email_accounts[0].smtp.tls: unknown value \"sometimes\"",
+				"email_accounts[0].smtp.auth: unknown value \"GSSAPI\"",
+				"email_accounts[1].smtp.username: is required for auth LOGIN",
+			},
+		},
+		{
+			name: "email routing",
+			yaml: "email_accounts:\n" +
+				"  - address: \"*@\"\n    smtp:\n      host: \"smtp\"\n      port: 25\n" +
+				"  - address_regex: \"noreply+(\"\n    envelope_from: \"bounces\"\n    smtp:\n      host: \"smtp\"\n      port: 25\n" +
+				"  - default: true\n    smtp:\n      host: \"smtp\"\n      port: 25\n" +
+				"    dkim:\n      selector: \"mds\"\n" +
+				"  - default: true\n    address: \"a@example.com\"\n    address_regex: \".*\"\n    smtp:\n      host: \"smtp\"\n      port: 25\n" +
+				"  - smtp:\n      host: \"smtp\"\n      port: 25\n",
+			want: []string{
+				"email_accounts[0].address: \"*@\" is not a valid email address or *@domain",
+				"email_accounts[1].address_regex: error parsing regexp",
+				"email_accounts[1].envelope_from: \"bounces\" is not a valid email address",
+				"email_accounts[2].dkim.domain: is required when the account has no address",
+				"email_accounts[3].address_regex: cannot be combined with address",
+				"email_accounts[3].default: email_accounts[2] is already the default",
+				"email_accounts[4].address: is required unless address_regex or default is set",
+			},
+		},
+		{
+			name: "duplicate routes",
+			yaml: "email_accounts:\n" +
+				"  - address_regex: \"^noreply\"\n    smtp:\n      host: \"smtp\"\n      port: 25\n" +
+				"  - address: \"*@example.com\"\n    smtp:\n      host: \"smtp\"\n      port: 25\n" +
+				"  - address_regex: \"^noreply\"\n    smtp:\n      host: \"smtp2\"\n      port: 25\n" +
+				"  - address: \"*@Example.com\"\n    smtp:\n      host: \"smtp2\"\n      port: 25\n",
+			want: []string{
+				"email_accounts[2].address_regex: duplicate of email_accounts[0]",
+				"email_accounts[3].address: duplicate of email_accounts[1]",
+			},
+		},
+		{
+			name: "dkim",
+			yaml: "email_accounts:\n" +
+				"  - address: \"a@example.com\"\n" +
+				"    smtp:\n      host: \"smtp\"\n      port: 25\n" +
+				"    dkim:\n      private_key: \"not a key\"\n      headers: [\"Subject\"]\n",
+			want: []string{
+				"email_accounts[0].dkim.selector: is required",
+				"email_accounts[0].dkim.private_key: no PEM block found",
+				"email_accounts[0].dkim.headers: must include From",
+			},
+		},
+		{
+			name: "smtp oauth2",
+			yaml: "email_accounts:\n" +
+				"  - address: \"a@example.com\"\n" +
+				"    smtp:\n      host: \"smtp\"\n      port: 587\n      username: \"a@example.com\"\n      auth: \"PLAIN\"\n" +
+				"      oauth2:\n        token_url: \"login.example.com/token\"\n        refresh_before: \"-1m\"\n" +
+				"  - address: \"b@example.com\"\n" +
+				"    smtp:\n      host: \"smtp\"\n      port: 587\n      username: \"b@example.com\"\n" +
+				"      oauth2:\n        client_id: \"mds\"\n",
+			want: []string{
+				"email_accounts[0].smtp.oauth2.token_url: \"login.example.com/token\" is not an http(s) URL",
+				"email_accounts[0].smtp.oauth2.client_id: is required",
+				"email_accounts[0].smtp.oauth2.refresh_before: must not be negative",
+				"email_accounts[0].smtp.auth: must be XOAUTH2 when oauth2 is configured, got PLAIN",
+				"email_accounts[1].smtp.oauth2.token_url: is required",
+			},
+		},
+		{
+			name: "bounces",
+			yaml: "email_accounts:\n" +
+				"  - address: \"a@example.com\"\n    verp: true\n    smtp:\n      host: \"smtp\"\n      port: 25\n" +
+				"  - address: \"b@example.com\"\n    verp: true\n    envelope_from: \"delivery-status-notifications@example.com\"\n" +
+				"    smtp:\n      host: \"smtp\"\n      port: 25\n" +
+				"bounces:\n  pop3:\n    host: \"pop\"\n    port: 0\n    username: \"bounces\"\n    tls: \"ssl\"\n",
+			want: []string{
+				"email_accounts[0].envelope_from: is required for verp",
+				"email_accounts[1].envelope_from: local part must be at most 26 characters for verp",
+				"bounces.pop3.port: must be between 1 and 65535, got 0",
+				"bounces.pop3.password: is required",
+				"bounces.pop3.tls: unknown value \"ssl\"",
+			},
+		},
+		{
+			name: "unsubscribe",
+			yaml: "unsubscribe:\n  base_url: \"mds.example.com\"\n  secret: \"short\"\n",
+			want: []string{
+				"unsubscribe.base_url: \"mds.example.com\" is not an http(s) URL",
+				"unsubscribe.secret: must be at least 16 characters",
+			},
+		},
+		{
+			name: "storage",
+			yaml: "storage:\n  driver: \"sqlite\"\n",
+			want: []string{"storage.driver: unknown value \"sqlite\""},
+		},
+		{
+			name: "quiet hours",
+			yaml: "services:\n" +
+				"  - id: \"app\"\n    client_cert_subjects: [\"app\"]\n" +
+				"    quiet_hours:\n      start: \"21:00\"\n      end: \"8\"\n      time_zone: \"Mars/Olympus\"\n" +
+				"  - id: \"other\"\n    client_cert_subjects: [\"other\"]\n    quiet_hours:\n      start: \"22:00\"\n      end: \"22:00\"\n",
+			want: []string{
+				"services[0].quiet_hours.end: \"8\" is not a time of day (expected HH:MM)",
+				"services[0].quiet_hours.time_zone: unknown time zone \"Mars/Olympus\"",
+				"services[1].quiet_hours.end: must differ from start",
+			},
+		},
+		{
+			name: "tls without key",
+			yaml: "server:\n  tls:\n    cert_file: \"tls.crt\"\n    client_auth: \"sometimes\"\n",
+			want: []string{
+				"server.tls.key_file: is required",
+				"server.tls.client_auth: unknown value \"sometimes\"",
+			},
+		},
+		{
+			name: "logging",
+			yaml: "logging:\n  level: \"verbose\"\n  format: \"xml\"\n",
+			want: []string{
+				"logging.level: unknown value \"verbose\"",
+				"logging.format: unknown value \"xml\"",
+			},
+		},
+		{
+			name: "tracing",
+			yaml: "tracing:\n  endpoint: \"otel-collector:4318\"\n  sample_ratio: 1.5\n",
+			want: []string{
+				"tracing.endpoint: \"otel-collector:4318\" is not an http(s) URL",
+				"tracing.sample_ratio: must be between 0 and 1, got 1.5",
+			},
+		},
+		{
+			name: "health",
+			yaml: "health:\n  interval: \"-1s\"\n  timeout: \"-5s\"\n",
+			want: []string{
+				"health.interval: must not be negative",
+				"health.timeout: must not be negative",
+			},
+		},
+		{
+			name: "partial 46elks credentials",
+			yaml: "sms:\n  46elks:\n    username: \"u\"\n",
+			want: []string{"sms.46elks: username and password must be set together"},
+		},
+		{
+			name: "duplicate service",
+			yaml: "services:\n  - id: \"a\"\n    client_cert_subjects: [\"a\"]\n  - id: \"a\"\n    client_cert_subjects: [\"b\"]\n",
+			want: []string{"services[1]: duplicate id \"a\""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.yaml))
+			if err == nil {
+				t.Fatal("Expected Parse to fail")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Expected error to contain %q, got:\n%v", want, err)
+				}
+			}
+		})
+	}
+}
+
+func TestParse_ReportsAllProblems(t *testing.T) {
+	_, err := Parse([]byte("server:\n  port: -1\n  max_body_bytes: -5\n"))
+
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Expected a ValidationError, got %v", err)
+	}
+	if len(verr.Problems) != 2 {
+		t.Errorf("Expected 2 problems, got %v", verr.Problems)
+	}
+}
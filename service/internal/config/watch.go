@@ -0,0 +1,133 @@
+package config
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce coalesces the bursts of events produced by a single save.
+const watchDebounce = 200 * time.Millisecond
+
+// Watch reloads the store's config file whenever it changes on disk or the
+// process receives SIGHUP, until ctx is cancelled.
+//
+// The parent directory is watched rather than the file itself so that saves
+// by rename (most editors) and Kubernetes ConfigMap updates, which atomically
+// swap a "..data" symlink, are picked up and do not break the watch.
+func (s *Store) Watch(ctx context.Context) error {
+	return s.watch(watchDebounce, ctx.Done())
+}
+
+func (s *Store) watch(debounce time.Duration, stop <-chan struct{}) error {
+	path, err := filepath.Abs(s.path)
+	if err != nil {
+		return err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		return err
+	}
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+
+	// Coalesce events into a single reload once things settle down.
+	reloadNow := make(chan struct{}, 1)
+	timer := time.AfterFunc(time.Hour, func() {
+		select {
+		case reloadNow <- struct{}{}:
+		default:
+		}
+	})
+	timer.Stop()
+
+	target := resolvedPath(path)
+
+	go func() {
+		defer watcher.Close()
+		defer signal.Stop(hup)
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if !relevant(event, path, &target) {
+					continue
+				}
+				s.logger.Debug("Config watcher event", "event", event.String())
+				timer.Reset(debounce)
+			case <-reloadNow:
+				s.reload(path, "file change")
+			case <-hup:
+				s.reload(path, "SIGHUP")
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				s.logger.Error("Config watcher error", "error", err)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// relevant reports whether a directory event may have changed the content
+// behind path. target tracks the resolved symlink destination of path so that
+// swaps of any link in the chain are noticed.
+func relevant(event fsnotify.Event, path string, target *string) bool {
+	if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) &&
+		!event.Has(fsnotify.Rename) && !event.Has(fsnotify.Remove) {
+		return false
+	}
+
+	name := filepath.Clean(event.Name)
+	if name == path || filepath.Base(name) == "..data" {
+		*target = resolvedPath(path)
+		return true
+	}
+
+	if resolved := resolvedPath(path); resolved != *target {
+		*target = resolved
+		return true
+	}
+	return false
+}
+
+func resolvedPath(path string) string {
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return ""
+	}
+	return resolved
+}
+
+func (s *Store) reload(path, reason string) {
+	// A rename-based save briefly removes the file. Never fall back to
+	// writing the default config during a reload.
+	if _, err := os.Stat(path); err != nil {
+		s.logger.Warn("Config file unavailable, keeping current config", "path", path, "error", err)
+		return
+	}
+
+	s.logger.Info("Reloading config", "path", path, "reason", reason)
+	if _, err := s.Load(); err != nil {
+		s.logger.Error("Config reload rejected, keeping current config", "path", path, "error", err)
+	}
+}
@@ -0,0 +1,163 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func accountConfig(address string) string {
+	return "email_accounts:\n" +
+		"  - address: \"" + address + "\"\n" +
+		"    smtp:\n" +
+		"      host: \"smtp.example.com\"\n" +
+		"      port: 587\n"
+}
+
+func startWatch(t *testing.T, path string, debounce time.Duration) *Store {
+	t.Helper()
+	store := NewStore(path)
+	if _, err := store.Load(); err != nil {
+		t.Fatalf("Initial load failed: %v", err)
+	}
+	stop := make(chan struct{})
+	if err := store.watch(debounce, stop); err != nil {
+		t.Fatalf("watch failed: %v", err)
+	}
+	t.Cleanup(func() { close(stop) })
+	return store
+}
+
+func waitForAccount(t *testing.T, store *Store, address string) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if cfg := store.Get(); len(cfg.EmailAccounts) > 0 && cfg.EmailAccounts[0].Address == address {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Timed out waiting for config with account %s", address)
+}
+
+func TestWatch_InPlaceWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, accountConfig("v1@example.com"))
+	store := startWatch(t, path, 20*time.Millisecond)
+
+	writeConfig(t, path, accountConfig("v2@example.com"))
+	waitForAccount(t, store, "v2@example.com")
+}
+
+func TestWatch_RenameSave(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	writeConfig(t, path, accountConfig("v1@example.com"))
+	store := startWatch(t, path, 20*time.Millisecond)
+
+	// Editors write a temporary file and rename it over the original; the
+	// watch must survive repeated saves.
+	for _, address := range []string{"v2@example.com", "v3@example.com"} {
+		tmp := filepath.Join(dir, ".config.yaml.swp")
+		writeConfig(t, tmp, accountConfig(address))
+		if err := os.Rename(tmp, path); err != nil {
+			t.Fatal(err)
+		}
+		waitForAccount(t, store, address)
+	}
+}
+
+func TestWatch_ConfigMapSymlinkSwap(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+
+	// Mimic the layout kubelet uses for ConfigMap volumes:
+	//   config.yaml -> ..data/config.yaml
+	//   ..data      -> ..<timestamp>
+	publish := func(version, address string) {
+		versionDir := filepath.Join(dir, version)
+		if err := os.Mkdir(versionDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		writeConfig(t, filepath.Join(versionDir, "config.yaml"), accountConfig(address))
+
+		tmpLink := filepath.Join(dir, "..data_tmp")
+		if err := os.Symlink(version, tmpLink); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Rename(tmpLink, filepath.Join(dir, "..data")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	publish("..2024_01", "v1@example.com")
+	if err := os.Symlink(filepath.Join("..data", "config.yaml"), path); err != nil {
+		t.Fatal(err)
+	}
+	store := startWatch(t, path, 20*time.Millisecond)
+
+	publish("..2024_02", "v2@example.com")
+	waitForAccount(t, store, "v2@example.com")
+
+	publish("..2024_03", "v3@example.com")
+	waitForAccount(t, store, "v3@example.com")
+}
+
+func TestWatch_RemovedFileKeepsConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, accountConfig("v1@example.com"))
+	store := startWatch(t, path, 20*time.Millisecond)
+
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatal("Reload must not recreate a removed config file")
+	}
+	waitForAccount(t, store, "v1@example.com")
+
+	writeConfig(t, path, accountConfig("v2@example.com"))
+	waitForAccount(t, store, "v2@example.com")
+}
+
+func TestWatch_DebouncesBursts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, accountConfig("v0@example.com"))
+	store := startWatch(t, path, 20*time.Millisecond)
+
+	var loads atomic.Int32
+	store.Subscribe(func(*Config) { loads.Add(1) })
+
+	for _, address := range []string{"v1@example.com", "v2@example.com", "v3@example.com", "v4@example.com"} {
+		writeConfig(t, path, accountConfig(address))
+	}
+	waitForAccount(t, store, "v4@example.com")
+	time.Sleep(100 * time.Millisecond)
+
+	if n := loads.Load(); n > 2 {
+		t.Errorf("Expected the burst to be coalesced, got %d reloads", n)
+	}
+}
+
+func TestWatch_SIGHUP(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, accountConfig("v1@example.com"))
+	// A debounce far beyond the test duration leaves SIGHUP as the only trigger.
+	store := startWatch(t, path, time.Hour)
+
+	writeConfig(t, path, accountConfig("v2@example.com"))
+	time.Sleep(50 * time.Millisecond)
+	if store.Get().EmailAccounts[0].Address != "v1@example.com" {
+		t.Fatal("Config reloaded before SIGHUP")
+	}
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGHUP); err != nil {
+		t.Fatal(err)
+	}
+	waitForAccount(t, store, "v2@example.com")
+}
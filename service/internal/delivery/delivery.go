@@ -0,0 +1,19 @@
+package delivery
+
+import (
+	"context"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/message"
+)
+
+// EmailSender delivers a single email to all of its recipients and returns
+// its Message-ID. EmailProvider implements it over SMTP.
+type EmailSender interface {
+	Send(ctx context.Context, email *message.Email) (messageID string, err error)
+}
+
+// SmsSender delivers an SMS to each recipient. SmsProvider implements it
+// with 46elks.
+type SmsSender interface {
+	Send(ctx context.Context, from string, to []string, body string) error
+}
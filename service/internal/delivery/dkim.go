@@ -0,0 +1,47 @@
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/config"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/tracing"
+	"github.com/emersion/go-msgauth/dkim"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// dkimSigner signs the messages of one account with relaxed/relaxed
+// canonicalization and SHA-256.
+type dkimSigner struct {
+	opts dkim.SignOptions
+}
+
+func newDKIMSigner(cfg config.DKIMConfig) (*dkimSigner, error) {
+	key, err := cfg.Signer()
+	if err != nil {
+		return nil, err
+	}
+	return &dkimSigner{opts: dkim.SignOptions{
+		Domain:                 cfg.Domain,
+		Selector:               cfg.Selector,
+		Signer:                 key,
+		Hash:                   crypto.SHA256,
+		HeaderCanonicalization: dkim.CanonicalizationRelaxed,
+		BodyCanonicalization:   dkim.CanonicalizationRelaxed,
+		HeaderKeys:             cfg.Headers,
+	}}, nil
+}
+
+// sign returns msg with a DKIM-Signature header prepended.
+func (s *dkimSigner) sign(ctx context.Context, msg []byte) ([]byte, error) {
+	_, span := tracing.Start(ctx, "dkim sign",
+		attribute.String("mds.dkim.domain", s.opts.Domain), attribute.String("mds.dkim.selector", s.opts.Selector))
+	var signed bytes.Buffer
+	err := dkim.Sign(&signed, bytes.NewReader(msg), &s.opts)
+	tracing.End(span, err)
+	if err != nil {
+		return nil, err
+	}
+	return signed.Bytes(), nil
+}
@@ -0,0 +1,101 @@
+package delivery
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/config"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/logging"
+	"github.com/emersion/go-msgauth/dkim"
+)
+
+func TestEmailProvider_SignsDKIM(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate RSA key: %v", err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate Ed25519 key: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		key    crypto.Signer
+		record string // DNS TXT record of the public key
+	}{
+		{"rsa", rsaKey, "v=DKIM1; k=rsa; p=" + publicKeyBase64(t, rsaKey.Public())},
+		{"ed25519", edKey, "v=DKIM1; k=ed25519; p=" + base64.StdEncoding.EncodeToString(edKey.Public().(ed25519.PublicKey))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := startMockSMTP(t)
+			account := testAccount("test@example.com", server.Port)
+			account.DKIM = config.DKIMConfig{
+				Domain:     "example.com",
+				Selector:   "mds",
+				PrivateKey: privateKeyPEM(t, tt.key),
+				Headers:    []string{"From", "To", "Subject"},
+			}
+			provider := NewEmailProvider(config.NewStaticStore(&config.Config{
+				EmailAccounts: []config.EmailAccountConfig{account},
+			}), logging.Discard())
+
+			if _, err := provider.Send(context.Background(), testEmail("test@example.com", "Hi", "Body", "r@example.com")); err != nil {
+				t.Fatalf("Send failed: %v", err)
+			}
+
+			var msg string
+			select {
+			case msg = <-server.Messages:
+			case <-time.After(2 * time.Second):
+				t.Fatal("Timeout waiting for email content")
+			}
+			verifications, err := dkim.VerifyWithOptions(strings.NewReader(msg), &dkim.VerifyOptions{
+				LookupTXT: func(domain string) ([]string, error) {
+					if domain != "mds._domainkey.example.com" {
+						return nil, fmt.Errorf("unexpected lookup of %s", domain)
+					}
+					return []string{tt.record}, nil
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to verify: %v", err)
+			}
+			if len(verifications) != 1 || verifications[0].Err != nil {
+				t.Fatalf("Expected one valid signature, got %+v", verifications)
+			}
+			if v := verifications[0]; v.Domain != "example.com" || strings.Join(v.HeaderKeys, ":") != "From:To:Subject" {
+				t.Errorf("Unexpected signature: domain %s, headers %v", v.Domain, v.HeaderKeys)
+			}
+		})
+	}
+}
+
+func privateKeyPEM(t *testing.T, key crypto.Signer) config.Secret {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+	return config.Secret(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
+
+func publicKeyBase64(t *testing.T, key crypto.PublicKey) string {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatalf("Failed to marshal public key: %v", err)
+	}
+	return base64.StdEncoding.EncodeToString(der)
+}
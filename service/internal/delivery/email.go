@@ -1,101 +1,163 @@
 package delivery
 
 import (
-	"crypto/tls"
+	"context"
 	"fmt"
-	"mime"
-	"net/smtp"
+	"log/slog"
+	"regexp"
+	"sort"
+	"sync"
+	"sync/atomic"
 
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/bounce"
 	"github.com/Low-Stack-Technologies/message-delivery-service/internal/config"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/health"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/message"
 )
 
 type EmailProvider struct {
-	accounts map[string]config.EmailAccountConfig
+	logger *slog.Logger
+
+	mu     sync.Mutex // serializes Reconfigure and Close
+	routes atomic.Pointer[emailRoutes]
+}
+
+// emailAccount is what the provider keeps per configured account.
+type emailAccount struct {
+	name         string
+	envelopeFrom string         // "" to use the From address
+	verp         bool           // encode the message ID in envelopeFrom
+	domain       string         // set for "*@domain" accounts
+	regex        *regexp.Regexp // set for address_regex accounts
+
+	pool *smtpPool
+	dkim *dkimSigner // nil without DKIM
+
+	// dkimErr fails sends when DKIM is configured with an unusable key,
+	// rather than sending them unsigned.
+	dkimErr error
 }
 
-func NewEmailProvider(cfg *config.Config) *EmailProvider {
-	accounts := make(map[string]config.EmailAccountConfig)
+// NewEmailProvider configures the provider from the store's active config and
+// reconfigures it whenever a new config is published.
+func NewEmailProvider(store *config.Store, logger *slog.Logger) *EmailProvider {
+	p := &EmailProvider{logger: logger}
+	p.Reconfigure(store.Get())
+	store.Subscribe(p.Reconfigure)
+	return p
+}
+
+// Reconfigure swaps in the email accounts from cfg. Accounts whose SMTP
+// settings are unchanged keep their connection pool; the pools of changed or
+// removed accounts are closed once their sends in progress complete.
+func (p *EmailProvider) Reconfigure(cfg *config.Config) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	old := make(map[string]*smtpPool)
+	if current := p.routes.Load(); current != nil {
+		for name, acc := range current.byName {
+			old[name] = acc.pool
+		}
+	}
+	routes := newEmailRoutes()
 	for _, acc := range cfg.EmailAccounts {
-		accounts[acc.Address] = acc
+		account := &emailAccount{name: acc.Name(), envelopeFrom: acc.EnvelopeFrom, verp: acc.VERP}
+		if pool, ok := old[account.name]; ok && pool.cfg == acc.SMTP {
+			account.pool = pool
+			delete(old, account.name)
+		} else {
+			account.pool = newSMTPPool(acc.SMTP)
+		}
+		if acc.DKIM.Enabled() {
+			if account.dkim, account.dkimErr = newDKIMSigner(acc.DKIM); account.dkimErr != nil {
+				p.logger.Error("DKIM key unusable, sends from the account will fail", "account", account.name, "error", account.dkimErr)
+			}
+		}
+		routes.add(acc, account)
 	}
-	return &EmailProvider{accounts: accounts}
+	p.routes.Store(routes)
+	for _, pool := range old {
+		pool.close()
+	}
+	p.logger.Debug("Email provider configured", "smtp_accounts", len(routes.byName), "default_account", routes.fallback != nil)
 }
 
-func (p *EmailProvider) Send(from string, to []string, subject string, body string, isHTML bool) error {
-	acc, ok := p.accounts[from]
+// Close closes the idle connections of every account.
+func (p *EmailProvider) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, acc := range p.routes.Load().byName {
+		acc.pool.close()
+	}
+	return nil
+}
+
+func (p *EmailProvider) Send(ctx context.Context, email *message.Email) (string, error) {
+	from := email.From.Address
+	account, ok := p.routes.Load().lookup(from)
 	if !ok {
-		config.DebugLog("[DEBUG] Email Delivery Failed - No account for: %s", from)
-		return fmt.Errorf("no SMTP account configured for sender: %s", from)
+		p.logger.DebugContext(ctx, "Email delivery failed: no account for sender", "from", from)
+		return "", fmt.Errorf("no SMTP account configured for sender: %s", from)
 	}
 
-	config.DebugLog("[DEBUG] Email Delivery - Using SMTP account: %s (%s:%d)", acc.Address, acc.SMTP.Host, acc.SMTP.Port)
+	pool := account.pool
+	p.logger.DebugContext(ctx, "Email delivery using SMTP account", "account", account.name, "host", pool.cfg.Host, "port", pool.cfg.Port)
 
-	contentType := "text/plain"
-	if isHTML {
-		contentType = "text/html"
+	msg, messageID, err := message.Build(email)
+	if err != nil {
+		return "", err
 	}
-
-	encodedSubject := mime.QEncoding.Encode("utf-8", subject)
-
-	msg := fmt.Sprintf("From: %s\r\n"+
-		"To: %s\r\n"+
-		"Subject: %s\r\n"+
-		"MIME-Version: 1.0\r\n"+
-		"Content-Type: %s; charset=\"UTF-8\"\r\n"+
-		"\r\n"+
-		"%s", from, to[0], encodedSubject, contentType, body)
-
-	auth := smtp.PlainAuth("", acc.SMTP.Username, acc.SMTP.Password, acc.SMTP.Host)
-	addr := fmt.Sprintf("%s:%d", acc.SMTP.Host, acc.SMTP.Port)
-
-	// Simple SMTP send
-	if acc.SMTP.Port == 465 {
-		// Implicit SSL/TLS
-		tlsConfig := &tls.Config{
-			InsecureSkipVerify: false,
-			ServerName:         acc.SMTP.Host,
-		}
-		conn, err := tls.Dial("tcp", addr, tlsConfig)
-		if err != nil {
-			return err
-		}
-		client, err := smtp.NewClient(conn, acc.SMTP.Host)
-		if err != nil {
-			return err
-		}
-		if err = client.Auth(auth); err != nil {
-			return err
+	if account.dkimErr != nil {
+		return "", fmt.Errorf("dkim: %w", account.dkimErr)
+	}
+	if account.dkim != nil {
+		if msg, err = account.dkim.sign(ctx, msg); err != nil {
+			p.logger.DebugContext(ctx, "Email DKIM signing failed", "error", err)
+			return "", fmt.Errorf("dkim: %w", err)
 		}
+	}
 
-		if err = client.Mail(from); err != nil {
-			return err
-		}
-		for _, addr := range to {
-			if err = client.Rcpt(addr); err != nil {
-				return err
+	envelopeFrom := from
+	if account.envelopeFrom != "" {
+		envelopeFrom = account.envelopeFrom
+		if account.verp {
+			var ok bool
+			if envelopeFrom, ok = bounce.VERPAddress(envelopeFrom, messageID); !ok {
+				p.logger.WarnContext(ctx, "Message ID cannot be encoded with VERP, bounces may not be matched to the message",
+					"message_id", messageID, "envelope_from", envelopeFrom)
 			}
 		}
-
-		w, err := client.Data()
-		if err != nil {
-			return err
-		}
-		_, err = w.Write([]byte(msg))
-		if err != nil {
-			return err
-		}
-		err = w.Close()
-		if err != nil {
-			return err
-		}
-
-		return client.Quit()
 	}
+	to := email.Recipients()
+	if err := pool.send(ctx, envelopeFrom, to, msg); err != nil {
+		p.logger.DebugContext(ctx, "Email delivery failed", "error", err)
+		return "", err
+	}
+	p.logger.DebugContext(ctx, "Email delivered", "to", to, "message_id", messageID)
+	return messageID, nil
+}
 
-	if err := smtp.SendMail(addr, auth, from, to, []byte(msg)); err != nil {
-		config.DebugLog("[DEBUG] Email Delivery Failed - SMTP Error: %v", err)
-		return err
+// Probes checks each SMTP account by connecting and authenticating, without
+// sending a message.
+func (p *EmailProvider) Probes() []health.Probe {
+	accounts := p.routes.Load().byName
+	probes := make([]health.Probe, 0, len(accounts))
+	for name, acc := range accounts {
+		pool := acc.pool
+		probes = append(probes, health.Probe{
+			Name: "smtp:" + name,
+			Kind: "smtp",
+			Check: func(ctx context.Context) error {
+				client, _, err := pool.connect(ctx)
+				if err != nil {
+					return err
+				}
+				defer client.Close()
+				return step(ctx, "smtp quit", client.Quit)
+			},
+		})
 	}
-	config.DebugLog("[DEBUG] Email Delivery Success - Sent to %v", to)
-	return nil
+	sort.Slice(probes, func(i, j int) bool { return probes[i].Name < probes[j].Name })
+	return probes
 }
@@ -1,122 +1,62 @@
 package delivery
 
 import (
-	"bufio"
-	"net"
+	"context"
+	"net/mail"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/Low-Stack-Technologies/message-delivery-service/internal/config"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/logging"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/message"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
-func TestEmailProvider_Send_SubjectEncoding(t *testing.T) {
-	// 1. Setup Mock SMTP Server
-	l, err := net.Listen("tcp", "127.0.0.1:0")
-	if err != nil {
-		t.Fatalf("Failed to listen: %v", err)
+func testAccount(address string, port int) config.EmailAccountConfig {
+	return config.EmailAccountConfig{
+		Address: address,
+		SMTP: config.SMTPConfig{
+			Host:     "127.0.0.1",
+			Port:     port,
+			Username: "user",
+			Password: "password",
+		},
 	}
-	defer l.Close()
-
-	port := l.Addr().(*net.TCPAddr).Port
-
-	// Channel to receive the message content
-	msgChan := make(chan string, 1)
-
-	go func() {
-		conn, err := l.Accept()
-		if err != nil {
-			return
-		}
-		defer conn.Close()
-
-		reader := bufio.NewReader(conn)
-		writer := bufio.NewWriter(conn)
-
-		// Simple SMTP Handshake
-		writer.WriteString("220 mock.smtp.server ESMTP\r\n")
-		writer.Flush()
-
-		// Read HELO/EHLO
-		reader.ReadString('\n')
-		writer.WriteString("250-Hello\r\n")
-		writer.WriteString("250 AUTH PLAIN\r\n")
-		writer.Flush()
-
-		// Read AUTH
-		reader.ReadString('\n')
-		writer.WriteString("235 Authentication successful\r\n")
-		writer.Flush()
-
-		// Read MAIL FROM
-		reader.ReadString('\n')
-		writer.WriteString("250 OK\r\n")
-		writer.Flush()
-
-		// Read RCPT TO
-		reader.ReadString('\n')
-		writer.WriteString("250 OK\r\n")
-		writer.Flush()
-
-		// Read DATA
-		reader.ReadString('\n')
-		writer.WriteString("354 End data with <CR><LF>.<CR><LF>\r\n")
-		writer.Flush()
-
-		// Read Body
-		var bodyBuilder strings.Builder
-		for {
-			line, err := reader.ReadString('\n')
-			if err != nil {
-				break
-			}
-			if line == ".\r\n" {
-				break
-			}
-			bodyBuilder.WriteString(line)
-		}
-		msgChan <- bodyBuilder.String()
+}
 
-		writer.WriteString("250 OK\r\n")
-		writer.Flush()
+func testEmail(from, subject, body string, to ...string) *message.Email {
+	email := &message.Email{From: mail.Address{Address: from}, Subject: subject, Body: body}
+	for _, addr := range to {
+		email.To = append(email.To, mail.Address{Address: addr})
+	}
+	return email
+}
 
-		// Read QUIT
-		reader.ReadString('\n')
-		writer.WriteString("221 Bye\r\n")
-		writer.Flush()
-	}()
+func TestEmailProvider_Send_SubjectEncoding(t *testing.T) {
+	// 1. Setup Mock SMTP Server
+	server := startMockSMTP(t)
 
 	// 2. Setup Config
 	cfg := &config.Config{
 		EmailAccounts: []config.EmailAccountConfig{
-			{
-				Address: "test@example.com",
-				SMTP: struct {
-					Host     string `yaml:"host"`
-					Port     int    `yaml:"port"`
-					Username string `yaml:"username"`
-					Password string `yaml:"password"`
-				}{
-					Host:     "127.0.0.1",
-					Port:     port,
-					Username: "user",
-					Password: "password",
-				},
-			},
+			testAccount("test@example.com", server.Port),
 		},
 	}
 
 	// 3. Send Email
-	provider := NewEmailProvider(cfg)
+	provider := NewEmailProvider(config.NewStaticStore(cfg), logging.Discard())
 	subject := "Test ÅÄÖ Subject"
-	err = provider.Send("test@example.com", []string{"recipient@example.com"}, subject, "Body content", false)
+	_, err := provider.Send(context.Background(), testEmail("test@example.com", subject, "Body content", "recipient@example.com"))
 	if err != nil {
 		t.Fatalf("Send failed: %v", err)
 	}
 
 	// 4. Verify Content
 	select {
-	case msg := <-msgChan:
+	case msg := <-server.Messages:
 		// We expect the subject to be encoded
 		if strings.Contains(msg, "Subject: Test ÅÄÖ Subject") {
 			t.Logf("Received message with raw subject:\n%s", msg)
@@ -134,3 +74,82 @@ func TestEmailProvider_Send_SubjectEncoding(t *testing.T) {
 		t.Fatal("Timeout waiting for email content")
 	}
 }
+
+func TestEmailProvider_FollowsStore(t *testing.T) {
+	server := startMockSMTP(t)
+
+	store := config.NewStaticStore(&config.Config{
+		EmailAccounts: []config.EmailAccountConfig{testAccount("old@example.com", server.Port)},
+	})
+	provider := NewEmailProvider(store, logging.Discard())
+
+	if _, err := provider.Send(context.Background(), testEmail("new@example.com", "Hi", "Body", "r@example.com")); err == nil {
+		t.Fatal("Expected send from an unconfigured account to fail")
+	}
+
+	store.Set(&config.Config{
+		EmailAccounts: []config.EmailAccountConfig{testAccount("new@example.com", server.Port)},
+	})
+
+	if _, err := provider.Send(context.Background(), testEmail("new@example.com", "Hi", "Body", "r@example.com")); err != nil {
+		t.Fatalf("Send after reconfigure failed: %v", err)
+	}
+	if _, err := provider.Send(context.Background(), testEmail("old@example.com", "Hi", "Body", "r@example.com")); err == nil {
+		t.Fatal("Expected removed account to be rejected after config change")
+	}
+}
+
+func TestEmailProvider_TracesSMTPSteps(t *testing.T) {
+	server := startMockSMTP(t)
+	provider := NewEmailProvider(config.NewStaticStore(&config.Config{
+		EmailAccounts: []config.EmailAccountConfig{testAccount("test@example.com", server.Port)},
+	}), logging.Discard())
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "queue email")
+
+	if _, err := provider.Send(ctx, testEmail("test@example.com", "Hi", "Body", "a@example.com", "b@example.com")); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	parent.End()
+
+	var names []string
+	for _, span := range recorder.Ended() {
+		if span.Name() != "queue email" && span.Parent().SpanID() != parent.SpanContext().SpanID() {
+			t.Errorf("Span %q is not a child of the delivery span", span.Name())
+		}
+		names = append(names, span.Name())
+	}
+	want := []string{"smtp dial", "smtp ehlo", "smtp auth", "smtp mail", "smtp data", "queue email"}
+	if !slices.Equal(names, want) {
+		t.Errorf("Expected spans %v, got %v", want, names)
+	}
+}
+
+func TestEmailProvider_Probes(t *testing.T) {
+	server := startMockSMTP(t)
+	closed := startMockSMTP(t)
+	closed.listener.Close()
+
+	provider := NewEmailProvider(config.NewStaticStore(&config.Config{
+		EmailAccounts: []config.EmailAccountConfig{
+			testAccount("up@example.com", server.Port),
+			testAccount("down@example.com", closed.Port),
+		},
+	}), logging.Discard())
+
+	probes := provider.Probes()
+	if len(probes) != 2 || probes[0].Name != "smtp:down@example.com" || probes[1].Name != "smtp:up@example.com" {
+		t.Fatalf("Unexpected probes: %+v", probes)
+	}
+	if err := probes[0].Check(context.Background()); err == nil {
+		t.Error("Expected the probe of an unreachable server to fail")
+	}
+	if err := probes[1].Check(context.Background()); err != nil {
+		t.Errorf("Probe failed: %v", err)
+	}
+	if got := strings.Join(server.Commands(), " "); got != "EHLO AUTH QUIT" {
+		t.Errorf("Expected the probe to authenticate without sending, got %s", got)
+	}
+}
@@ -0,0 +1,261 @@
+package delivery
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/base64"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// mockSMTP is a minimal SMTP server for tests. It accepts any number of
+// connections, answers the common commands and records each message body
+// received via DATA.
+type mockSMTP struct {
+	listener net.Listener
+	Port     int
+
+	// Messages receives the content of every DATA command.
+	Messages chan string
+
+	mu          sync.Mutex
+	dataDelay   time.Duration
+	commands    []string
+	connections int
+	open        map[net.Conn]bool
+	replies     map[string]string // one-shot replies by verb
+	mechanisms  string            // advertised AUTH mechanisms, none if empty
+	auths       []string          // decoded credentials per AUTH exchange
+	senders     []string          // MAIL FROM addresses
+	tlsConfig   *tls.Config
+	implicitTLS bool
+}
+
+func startMockSMTP(t *testing.T) *mockSMTP {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { l.Close() })
+
+	m := &mockSMTP{
+		listener:   l,
+		Port:       l.Addr().(*net.TCPAddr).Port,
+		Messages:   make(chan string, 16),
+		open:       make(map[net.Conn]bool),
+		replies:    make(map[string]string),
+		mechanisms: "PLAIN",
+	}
+	go m.serve()
+	return m
+}
+
+// Commands returns the verbs received so far, across all connections.
+func (m *mockSMTP) Commands() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.commands...)
+}
+
+// Connections returns the number of connections accepted so far.
+func (m *mockSMTP) Connections() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.connections
+}
+
+// ReplyNext answers the next command with verb with reply instead of the
+// usual one. A 421 reply closes the connection.
+func (m *mockSMTP) ReplyNext(verb, reply string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.replies[verb] = reply
+}
+
+// DelayData holds the reply to each message for d, to keep sessions busy.
+func (m *mockSMTP) DelayData(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.dataDelay = d
+}
+
+// OfferAuth sets the advertised AUTH mechanisms; "" advertises none.
+func (m *mockSMTP) OfferAuth(mechanisms string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.mechanisms = mechanisms
+}
+
+// UseTLS secures new connections with cfg: from the start when implicit is
+// set, otherwise by offering STARTTLS.
+func (m *mockSMTP) UseTLS(cfg *tls.Config, implicit bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tlsConfig = cfg
+	m.implicitTLS = implicit
+}
+
+// Auths returns the credentials received so far, as the mechanism name
+// followed by the decoded client responses.
+func (m *mockSMTP) Auths() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.auths...)
+}
+
+// Senders returns the envelope senders received so far.
+func (m *mockSMTP) Senders() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.senders...)
+}
+
+// Drop closes every open connection without a goodbye.
+func (m *mockSMTP) Drop() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for conn := range m.open {
+		conn.Close()
+	}
+}
+
+func (m *mockSMTP) serve() {
+	for {
+		conn, err := m.listener.Accept()
+		if err != nil {
+			return
+		}
+		go m.handle(conn)
+	}
+}
+
+func (m *mockSMTP) handle(conn net.Conn) {
+	m.mu.Lock()
+	m.connections++
+	m.open[conn] = true
+	tlsConfig, secure := m.tlsConfig, m.implicitTLS
+	m.mu.Unlock()
+	defer func() {
+		m.mu.Lock()
+		delete(m.open, conn)
+		m.mu.Unlock()
+		conn.Close()
+	}()
+
+	if secure {
+		conn = tls.Server(conn, tlsConfig)
+	}
+	reader := bufio.NewReader(conn)
+	writer := bufio.NewWriter(conn)
+	reply := func(lines ...string) {
+		for _, l := range lines {
+			writer.WriteString(l + "\r\n")
+		}
+		writer.Flush()
+	}
+	// challenge sends a 334 challenge and returns the decoded response.
+	challenge := func(text string) string {
+		reply("334 " + base64.StdEncoding.EncodeToString([]byte(text)))
+		line, _ := reader.ReadString('\n')
+		decoded, _ := base64.StdEncoding.DecodeString(strings.TrimSpace(line))
+		return string(decoded)
+	}
+
+	reply("220 mock.smtp.server ESMTP")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		verb := strings.ToUpper(strings.Fields(line + " x")[0])
+
+		m.mu.Lock()
+		m.commands = append(m.commands, verb)
+		override, ok := m.replies[verb]
+		delete(m.replies, verb)
+		m.mu.Unlock()
+
+		if ok {
+			reply(override)
+			if strings.HasPrefix(override, "421") {
+				return
+			}
+			continue
+		}
+
+		switch verb {
+		case "EHLO", "HELO":
+			lines := []string{"250-Hello"}
+			if tlsConfig != nil && !secure {
+				lines = append(lines, "250-STARTTLS")
+			}
+			m.mu.Lock()
+			if m.mechanisms != "" {
+				lines = append(lines, "250-AUTH "+m.mechanisms)
+			}
+			m.mu.Unlock()
+			lines[len(lines)-1] = "250 " + lines[len(lines)-1][4:]
+			reply(lines...)
+		case "STARTTLS":
+			reply("220 Ready to start TLS")
+			conn = tls.Server(conn, tlsConfig)
+			reader = bufio.NewReader(conn)
+			writer = bufio.NewWriter(conn)
+			secure = true
+		case "AUTH":
+			args := strings.Fields(line)[1:]
+			mech := strings.ToUpper(args[0])
+			auth := mech
+			switch {
+			case mech == "LOGIN":
+				auth += " " + challenge("Username:") + " " + challenge("Password:")
+			case mech == "CRAM-MD5":
+				auth += " " + challenge("<1896.697170952@mock.smtp.server>")
+			case len(args) > 1:
+				decoded, _ := base64.StdEncoding.DecodeString(args[1])
+				auth += " " + string(decoded)
+			default:
+				auth += " " + challenge("")
+			}
+			m.mu.Lock()
+			m.auths = append(m.auths, auth)
+			m.mu.Unlock()
+			reply("235 Authentication successful")
+		case "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var body strings.Builder
+			for {
+				line, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+				body.WriteString(line)
+			}
+			m.Messages <- body.String()
+			m.mu.Lock()
+			delay := m.dataDelay
+			m.mu.Unlock()
+			time.Sleep(delay)
+			reply("250 OK")
+		case "MAIL":
+			_, addr, _ := strings.Cut(strings.TrimSpace(line), ":")
+			m.mu.Lock()
+			m.senders = append(m.senders, strings.Trim(strings.Fields(addr)[0], "<>"))
+			m.mu.Unlock()
+			reply("250 OK")
+		case "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/config"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// maxTokenResponseBytes bounds the token endpoint response that is read.
+const maxTokenResponseBytes = 1 << 20
+
+// oauthTokens caches the XOAUTH2 access token of one SMTP account and
+// fetches a new one when it is about to expire.
+type oauthTokens struct {
+	cfg config.SMTPOAuth2Config
+
+	mu           sync.Mutex // held during fetches, so concurrent callers share one
+	token        string
+	expiry       time.Time // zero when the endpoint gave no lifetime
+	refreshToken string    // the configured one, until the endpoint rotates it
+}
+
+func newOAuthTokens(cfg config.SMTPOAuth2Config) *oauthTokens {
+	return &oauthTokens{cfg: cfg, refreshToken: cfg.RefreshToken.Value()}
+}
+
+// grant returns the OAuth2 grant type used for the account.
+func (t *oauthTokens) grant() string {
+	if t.cfg.RefreshToken != "" {
+		return "refresh_token"
+	}
+	return "client_credentials"
+}
+
+// Token returns a cached access token, or fetches one when none is cached or
+// the cached one expires within RefreshBefore. While the endpoint fails, a
+// token that has not yet expired is still returned.
+func (t *oauthTokens) Token(ctx context.Context) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	refreshBefore := t.cfg.RefreshBefore
+	if refreshBefore <= 0 {
+		refreshBefore = config.DefaultOAuth2RefreshBefore
+	}
+	if t.token != "" && (t.expiry.IsZero() || time.Until(t.expiry) > refreshBefore) {
+		return t.token, nil
+	}
+
+	ctx, span := tracing.Start(ctx, "oauth2 token", attribute.String("mds.oauth2.grant", t.grant()))
+	err := t.fetch(ctx)
+	tracing.End(span, err)
+	if err != nil {
+		if t.token != "" && time.Now().Before(t.expiry) {
+			return t.token, nil
+		}
+		return "", err
+	}
+	return t.token, nil
+}
+
+// Invalidate drops the cached token after the server rejected it.
+func (t *oauthTokens) Invalidate() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.token = ""
+	t.expiry = time.Time{}
+}
+
+func (t *oauthTokens) fetch(ctx context.Context) error {
+	// 1. Request
+	form := url.Values{"grant_type": {t.grant()}, "client_id": {t.cfg.ClientID}}
+	if t.cfg.ClientSecret != "" {
+		form.Set("client_secret", t.cfg.ClientSecret.Value())
+	}
+	if t.cfg.Scope != "" {
+		form.Set("scope", t.cfg.Scope)
+	}
+	if t.grant() == "refresh_token" {
+		form.Set("refresh_token", t.refreshToken)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.cfg.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("oauth2: token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// 2. Response
+	var body struct {
+		AccessToken      string `json:"access_token"`
+		ExpiresIn        int64  `json:"expires_in"`
+		RefreshToken     string `json:"refresh_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	decodeErr := json.NewDecoder(io.LimitReader(resp.Body, maxTokenResponseBytes)).Decode(&body)
+	if resp.StatusCode != http.StatusOK {
+		if body.Error != "" {
+			return fmt.Errorf("oauth2: token endpoint returned %d: %s: %s", resp.StatusCode, body.Error, body.ErrorDescription)
+		}
+		return fmt.Errorf("oauth2: token endpoint returned %d", resp.StatusCode)
+	}
+	if decodeErr != nil {
+		return fmt.Errorf("oauth2: invalid token response: %w", decodeErr)
+	}
+	if body.AccessToken == "" {
+		return fmt.Errorf("oauth2: token response has no access_token")
+	}
+
+	// 3. Cache
+	t.token = body.AccessToken
+	t.expiry = time.Time{}
+	if body.ExpiresIn > 0 {
+		t.expiry = time.Now().Add(time.Duration(body.ExpiresIn) * time.Second)
+	}
+	if body.RefreshToken != "" {
+		t.refreshToken = body.RefreshToken
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/config"
+)
+
+// mockTokenEndpoint is a stand-in OAuth2 token endpoint. It issues the
+// tokens "token-1", "token-2", ... and records each request's form.
+type mockTokenEndpoint struct {
+	URL string
+
+	mu        sync.Mutex
+	requests  []url.Values
+	expiresIn int
+	rotate    bool // issue a new refresh token with every access token
+	fail      bool
+}
+
+func startMockTokenEndpoint(t *testing.T) *mockTokenEndpoint {
+	t.Helper()
+	m := &mockTokenEndpoint{expiresIn: 3600}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("Failed to parse token request: %v", err)
+		}
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.requests = append(m.requests, r.PostForm)
+
+		w.Header().Set("Content-Type", "application/json")
+		if m.fail {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_client", "error_description": "Client secret expired"})
+			return
+		}
+		n := len(m.requests)
+		resp := map[string]any{"access_token": fmt.Sprintf("token-%d", n), "token_type": "Bearer", "expires_in": m.expiresIn}
+		if m.rotate {
+			resp["refresh_token"] = fmt.Sprintf("refresh-%d", n)
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+	m.URL = server.URL
+	return m
+}
+
+func (m *mockTokenEndpoint) Requests() []url.Values {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]url.Values(nil), m.requests...)
+}
+
+func (m *mockTokenEndpoint) set(fn func(*mockTokenEndpoint)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	fn(m)
+}
+
+func TestOAuthTokens_ClientCredentials(t *testing.T) {
+	endpoint := startMockTokenEndpoint(t)
+	tokens := newOAuthTokens(config.SMTPOAuth2Config{
+		TokenURL:     endpoint.URL,
+		ClientID:     "mds",
+		ClientSecret: "secret",
+		Scope:        "https://outlook.office365.com/.default",
+	})
+
+	for i := 0; i < 3; i++ {
+		token, err := tokens.Token(context.Background())
+		if err != nil {
+			t.Fatalf("Failed to get token: %v", err)
+		}
+		if token != "token-1" {
+			t.Errorf("Expected the cached token, got %q", token)
+		}
+	}
+
+	requests := endpoint.Requests()
+	if len(requests) != 1 {
+		t.Fatalf("Expected one token request, got %d", len(requests))
+	}
+	form := requests[0]
+	if form.Get("grant_type") != "client_credentials" || form.Get("client_id") != "mds" ||
+		form.Get("client_secret") != "secret" || form.Get("scope") != "https://outlook.office365.com/.default" {
+		t.Errorf("Unexpected token request: %v", form)
+	}
+}
+
+func TestOAuthTokens_RefreshesAheadOfExpiry(t *testing.T) {
+	endpoint := startMockTokenEndpoint(t)
+	endpoint.set(func(m *mockTokenEndpoint) { m.expiresIn = 120 })
+	tokens := newOAuthTokens(config.SMTPOAuth2Config{TokenURL: endpoint.URL, ClientID: "mds", RefreshBefore: time.Minute})
+
+	if token, _ := tokens.Token(context.Background()); token != "token-1" {
+		t.Fatalf("Expected token-1, got %q", token)
+	}
+	if token, _ := tokens.Token(context.Background()); token != "token-1" {
+		t.Errorf("Expected the token to be reused while far from expiry, got %q", token)
+	}
+
+	tokens.cfg.RefreshBefore = 5 * time.Minute
+	if token, _ := tokens.Token(context.Background()); token != "token-2" {
+		t.Errorf("Expected a new token within refresh_before of expiry, got %q", token)
+	}
+
+	// While the endpoint fails, the old token is used until it expires.
+	endpoint.set(func(m *mockTokenEndpoint) { m.fail = true })
+	if token, err := tokens.Token(context.Background()); err != nil || token != "token-2" {
+		t.Errorf("Expected the unexpired token despite the failure, got %q, %v", token, err)
+	}
+	tokens.Invalidate()
+	_, err := tokens.Token(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "invalid_client: Client secret expired") {
+		t.Errorf("Expected the endpoint's error, got %v", err)
+	}
+}
+
+func TestOAuthTokens_RefreshToken(t *testing.T) {
+	endpoint := startMockTokenEndpoint(t)
+	endpoint.set(func(m *mockTokenEndpoint) { m.rotate = true })
+	tokens := newOAuthTokens(config.SMTPOAuth2Config{TokenURL: endpoint.URL, ClientID: "mds", RefreshToken: "refresh-0"})
+
+	for i := 0; i < 2; i++ {
+		if _, err := tokens.Token(context.Background()); err != nil {
+			t.Fatalf("Failed to get token: %v", err)
+		}
+		tokens.Invalidate()
+	}
+
+	var got []string
+	for _, form := range endpoint.Requests() {
+		if form.Get("grant_type") != "refresh_token" {
+			t.Errorf("Expected the refresh_token grant, got %v", form)
+		}
+		got = append(got, form.Get("refresh_token"))
+	}
+	if want := []string{"refresh-0", "refresh-1"}; !slices.Equal(got, want) {
+		t.Errorf("Expected refresh tokens %v (rotated), got %v", want, got)
+	}
+}
+
+func TestPool_OAuth2(t *testing.T) {
+	endpoint := startMockTokenEndpoint(t)
+	server := startMockSMTP(t)
+	server.OfferAuth("XOAUTH2")
+	p := newTestPool(t, server, config.SMTPPoolConfig{})
+	p.cfg.OAuth2 = config.SMTPOAuth2Config{TokenURL: endpoint.URL, ClientID: "mds"}
+	p.tokens = newOAuthTokens(p.cfg.OAuth2)
+
+	// A rejected token is replaced on the next connection.
+	server.ReplyNext("AUTH", "535 5.7.3 Authentication unsuccessful")
+	if err := p.send(context.Background(), "test@example.com", []string{"r@example.com"}, []byte("Body")); err == nil {
+		t.Fatal("Expected the rejected token to fail the send")
+	}
+	sendTest(t, p)
+
+	want := []string{"XOAUTH2 user=user\x01auth=Bearer token-2\x01\x01"}
+	if got := server.Auths(); !slices.Equal(got, want) {
+		t.Errorf("Expected auth %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,277 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"sync"
+	"time"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/config"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// quitTimeout bounds the QUIT sent when a pooled session is closed.
+const quitTimeout = 5 * time.Second
+
+// defaultSendTimeout bounds a send whose context has no deadline, such as a
+// scheduled or queued one, so a stalled server cannot hold a session forever.
+const defaultSendTimeout = 2 * time.Minute
+
+// smtpPool reuses authenticated sessions with one account's server. At most
+// MaxConnections sessions are open at a time; idle sessions are closed after
+// IdleTimeout.
+type smtpPool struct {
+	cfg         config.SMTPConfig
+	idleTimeout time.Duration
+	sendTimeout time.Duration // used when the context has no deadline
+	tokens      *oauthTokens  // nil unless the account uses OAuth2
+
+	// slots holds a token for every session in use. Idle sessions hold
+	// none, so busy plus idle sessions never exceed its capacity.
+	slots chan struct{}
+
+	mu     sync.Mutex
+	idle   []*smtpConn // oldest first
+	reaper *time.Timer
+	closed bool
+}
+
+// smtpConn is one pooled session.
+type smtpConn struct {
+	client    *smtp.Client
+	conn      net.Conn
+	used      bool // a message was sent, so the next one starts with RSET
+	idleSince time.Time
+}
+
+func newSMTPPool(cfg config.SMTPConfig) *smtpPool {
+	max := cfg.Pool.MaxConnections
+	if max <= 0 {
+		max = config.DefaultSMTPMaxConnections
+	}
+	idle := cfg.Pool.IdleTimeout
+	if idle <= 0 {
+		idle = config.DefaultSMTPIdleTimeout
+	}
+	p := &smtpPool{cfg: cfg, idleTimeout: idle, sendTimeout: defaultSendTimeout, slots: make(chan struct{}, max)}
+	if cfg.OAuth2.Enabled() {
+		p.tokens = newOAuthTokens(cfg.OAuth2)
+	}
+	return p
+}
+
+// connect opens a new session outside the pool.
+func (p *smtpPool) connect(ctx context.Context) (*smtp.Client, net.Conn, error) {
+	return connectSMTP(ctx, p.cfg, p.tokens)
+}
+
+// send delivers msg over a pooled session. A reused session may have been
+// closed by the server in the meantime (421 or a broken connection); it is
+// then replaced and the message retried once, provided the server cannot
+// have accepted it yet.
+func (p *smtpPool) send(ctx context.Context, from string, to []string, msg []byte) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.sendTimeout)
+		defer cancel()
+	}
+
+	c, err := p.acquire(ctx, false)
+	if err != nil {
+		return err
+	}
+	reused := c.used
+	committed, err := c.send(ctx, from, to, msg)
+	if err != nil && reused && !committed && connectionLost(err) {
+		p.release(c, false)
+		if c, err = p.acquire(ctx, true); err != nil {
+			return err
+		}
+		_, err = c.send(ctx, from, to, msg)
+	}
+	p.release(c, err == nil || !connectionLost(err))
+	return err
+}
+
+// acquire waits for a free slot and returns an idle session, or a new one
+// when none is idle or fresh is set.
+func (p *smtpPool) acquire(ctx context.Context, fresh bool) (*smtpConn, error) {
+	select {
+	case p.slots <- struct{}{}:
+	default:
+		_, span := tracing.Start(ctx, "smtp pool wait", attribute.Int("mds.pool.max_connections", cap(p.slots)))
+		select {
+		case p.slots <- struct{}{}:
+			span.End()
+		case <-ctx.Done():
+			tracing.End(span, ctx.Err())
+			return nil, ctx.Err()
+		}
+	}
+
+	var c *smtpConn
+	if !fresh {
+		c = p.popIdle()
+	}
+	if c == nil {
+		client, conn, err := p.connect(ctx)
+		if err != nil {
+			<-p.slots
+			return nil, err
+		}
+		c = &smtpConn{client: client, conn: conn}
+	}
+
+	// Sessions outlive the request that opened them, so the deadline is
+	// set for every use.
+	deadline, _ := ctx.Deadline()
+	c.conn.SetDeadline(deadline)
+	return c, nil
+}
+
+// release returns c to the pool, or closes it when it is no longer usable.
+func (p *smtpPool) release(c *smtpConn, reusable bool) {
+	defer func() { <-p.slots }()
+
+	p.mu.Lock()
+	if !reusable || p.closed {
+		p.mu.Unlock()
+		c.close()
+		return
+	}
+	c.idleSince = time.Now()
+	p.idle = append(p.idle, c)
+	p.scheduleReapLocked()
+	p.mu.Unlock()
+}
+
+// popIdle returns the most recently used idle session that has not expired.
+func (p *smtpPool) popIdle() *smtpConn {
+	p.mu.Lock()
+	expired := p.expireLocked(time.Now())
+	var c *smtpConn
+	if n := len(p.idle); n > 0 {
+		c, p.idle = p.idle[n-1], p.idle[:n-1]
+	}
+	p.mu.Unlock()
+
+	for _, e := range expired {
+		e.close()
+	}
+	return c
+}
+
+// expireLocked removes and returns the sessions idle for longer than the
+// idle timeout.
+func (p *smtpPool) expireLocked(now time.Time) []*smtpConn {
+	n := 0
+	for n < len(p.idle) && now.Sub(p.idle[n].idleSince) >= p.idleTimeout {
+		n++
+	}
+	expired := append([]*smtpConn(nil), p.idle[:n]...)
+	p.idle = p.idle[n:]
+	return expired
+}
+
+func (p *smtpPool) scheduleReapLocked() {
+	if p.reaper != nil || len(p.idle) == 0 {
+		return
+	}
+	p.reaper = time.AfterFunc(time.Until(p.idle[0].idleSince.Add(p.idleTimeout)), p.reap)
+}
+
+func (p *smtpPool) reap() {
+	p.mu.Lock()
+	p.reaper = nil
+	expired := p.expireLocked(time.Now())
+	if !p.closed {
+		p.scheduleReapLocked()
+	}
+	p.mu.Unlock()
+
+	for _, c := range expired {
+		c.close()
+	}
+}
+
+// close closes the idle sessions. Sessions in use are closed when they are
+// released.
+func (p *smtpPool) close() {
+	p.mu.Lock()
+	p.closed = true
+	idle := p.idle
+	p.idle = nil
+	if p.reaper != nil {
+		p.reaper.Stop()
+		p.reaper = nil
+	}
+	p.mu.Unlock()
+
+	for _, c := range idle {
+		c.close()
+	}
+}
+
+// send runs one mail transaction on the session. committed reports whether
+// the message was handed over, after which the server may have accepted it
+// even if an error follows.
+func (c *smtpConn) send(ctx context.Context, from string, to []string, msg []byte) (committed bool, err error) {
+	// 1. Reset
+	if c.used {
+		if err := step(ctx, "smtp rset", c.client.Reset); err != nil {
+			return false, err
+		}
+	}
+	c.used = true
+
+	// 2. Envelope
+	err = step(ctx, "smtp mail", func() error {
+		if err := c.client.Mail(from); err != nil {
+			return err
+		}
+		for _, rcpt := range to {
+			if err := c.client.Rcpt(rcpt); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, attribute.Int("mds.recipients", len(to)))
+	if err != nil {
+		return false, err
+	}
+
+	// 3. Message
+	err = step(ctx, "smtp data", func() error {
+		w, err := c.client.Data()
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(msg); err != nil {
+			return err
+		}
+		committed = true
+		return w.Close()
+	}, attribute.Int("mds.message_bytes", len(msg)))
+	return committed, err
+}
+
+func (c *smtpConn) close() {
+	c.conn.SetDeadline(time.Now().Add(quitTimeout))
+	c.client.Quit()
+	c.client.Close()
+}
+
+// connectionLost reports whether err means the session can no longer be
+// used: the server announced it is closing (421) or the connection broke.
+// Other SMTP replies leave the session usable.
+func connectionLost(err error) bool {
+	var reply *textproto.Error
+	if errors.As(err, &reply) {
+		return reply.Code == 421
+	}
+	return true
+}
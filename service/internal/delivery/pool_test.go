@@ -0,0 +1,151 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/config"
+)
+
+func newTestPool(t *testing.T, server *mockSMTP, pool config.SMTPPoolConfig) *smtpPool {
+	t.Helper()
+	cfg := testAccount("test@example.com", server.Port).SMTP
+	cfg.Pool = pool
+	p := newSMTPPool(cfg)
+	t.Cleanup(p.close)
+	return p
+}
+
+func sendTest(t *testing.T, p *smtpPool) {
+	t.Helper()
+	if err := p.send(context.Background(), "test@example.com", []string{"r@example.com"}, []byte("Subject: Hi\r\n\r\nBody")); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+}
+
+func TestPool_ReusesConnection(t *testing.T) {
+	server := startMockSMTP(t)
+	p := newTestPool(t, server, config.SMTPPoolConfig{})
+
+	for i := 0; i < 3; i++ {
+		sendTest(t, p)
+	}
+
+	if n := server.Connections(); n != 1 {
+		t.Errorf("Expected one connection, got %d", n)
+	}
+	want := "EHLO AUTH MAIL RCPT DATA RSET MAIL RCPT DATA RSET MAIL RCPT DATA"
+	if got := strings.Join(server.Commands(), " "); got != want {
+		t.Errorf("Expected commands %q, got %q", want, got)
+	}
+}
+
+func TestPool_LimitsConnections(t *testing.T) {
+	server := startMockSMTP(t)
+	server.DelayData(50 * time.Millisecond)
+	p := newTestPool(t, server, config.SMTPPoolConfig{MaxConnections: 2})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := p.send(context.Background(), "test@example.com", []string{"r@example.com"}, []byte("Body")); err != nil {
+				t.Errorf("Send failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := server.Connections(); n != 2 {
+		t.Errorf("Expected 2 connections, got %d", n)
+	}
+	if n := len(server.Messages); n != 6 {
+		t.Errorf("Expected 6 messages, got %d", n)
+	}
+}
+
+func TestPool_Reconnects(t *testing.T) {
+	tests := []struct {
+		name      string
+		interrupt func(*mockSMTP)
+	}{
+		{"421 reply", func(s *mockSMTP) { s.ReplyNext("RSET", "421 Service closing transmission channel") }},
+		{"broken connection", func(s *mockSMTP) { s.Drop() }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := startMockSMTP(t)
+			p := newTestPool(t, server, config.SMTPPoolConfig{})
+
+			sendTest(t, p)
+			tt.interrupt(server)
+			sendTest(t, p)
+
+			if n := server.Connections(); n != 2 {
+				t.Errorf("Expected a second connection, got %d", n)
+			}
+			if n := len(server.Messages); n != 2 {
+				t.Errorf("Expected 2 messages, got %d", n)
+			}
+		})
+	}
+}
+
+func TestPool_KeepsConnectionAfterRejection(t *testing.T) {
+	server := startMockSMTP(t)
+	p := newTestPool(t, server, config.SMTPPoolConfig{})
+
+	server.ReplyNext("RCPT", "550 No such user")
+	if err := p.send(context.Background(), "test@example.com", []string{"nobody@example.com"}, []byte("Body")); err == nil {
+		t.Fatal("Expected the rejected recipient to fail the send")
+	}
+	sendTest(t, p)
+
+	if n := server.Connections(); n != 1 {
+		t.Errorf("Expected the connection to be reused, got %d connections", n)
+	}
+}
+
+func TestPool_TimesOutStalledServer(t *testing.T) {
+	server := startMockSMTP(t)
+	server.DelayData(200 * time.Millisecond)
+	p := newTestPool(t, server, config.SMTPPoolConfig{MaxConnections: 1})
+	p.sendTimeout = 50 * time.Millisecond
+
+	err := p.send(context.Background(), "test@example.com", []string{"r@example.com"}, []byte("Body"))
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Fatalf("Expected the stalled send to time out, got %v", err)
+	}
+
+	// The stalled session is dropped, so its slot is free again.
+	server.DelayData(0)
+	p.sendTimeout = defaultSendTimeout
+	sendTest(t, p)
+}
+
+func TestPool_ClosesIdleConnections(t *testing.T) {
+	server := startMockSMTP(t)
+	p := newTestPool(t, server, config.SMTPPoolConfig{IdleTimeout: 20 * time.Millisecond})
+
+	sendTest(t, p)
+	deadline := time.Now().Add(2 * time.Second)
+	for !slices.Contains(server.Commands(), "QUIT") {
+		if time.Now().After(deadline) {
+			t.Fatal("Idle connection was not closed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	sendTest(t, p)
+	if n := server.Connections(); n != 2 {
+		t.Errorf("Expected a new connection after the idle timeout, got %d", n)
+	}
+}
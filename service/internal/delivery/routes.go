@@ -0,0 +1,62 @@
+package delivery
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/config"
+)
+
+// emailRoutes selects the account for a sender address: exact addresses
+// first, then "*@domain" and regex accounts in config order, then the
+// default account.
+type emailRoutes struct {
+	byName   map[string]*emailAccount // every account, by config.EmailAccountConfig.Name
+	exact    map[string]*emailAccount // by lower-case address
+	patterns []*emailAccount
+	fallback *emailAccount
+}
+
+func newEmailRoutes() *emailRoutes {
+	return &emailRoutes{byName: make(map[string]*emailAccount), exact: make(map[string]*emailAccount)}
+}
+
+// add registers account under the senders cfg matches.
+func (r *emailRoutes) add(cfg config.EmailAccountConfig, account *emailAccount) {
+	r.byName[account.name] = account
+	switch {
+	case strings.HasPrefix(cfg.Address, config.DomainWildcard+"@"):
+		account.domain = cfg.Domain()
+		r.patterns = append(r.patterns, account)
+	case cfg.Address != "":
+		r.exact[strings.ToLower(cfg.Address)] = account
+	case cfg.AddressRegex != "":
+		// Validation has compiled the expression already.
+		account.regex = regexp.MustCompile(`^(?:` + cfg.AddressRegex + `)$`)
+		r.patterns = append(r.patterns, account)
+	}
+	if cfg.Default {
+		r.fallback = account
+	}
+}
+
+// lookup returns the account for the sender address from.
+func (r *emailRoutes) lookup(from string) (*emailAccount, bool) {
+	if account, ok := r.exact[strings.ToLower(from)]; ok {
+		return account, true
+	}
+	for _, account := range r.patterns {
+		if account.matches(from) {
+			return account, true
+		}
+	}
+	return r.fallback, r.fallback != nil
+}
+
+func (a *emailAccount) matches(from string) bool {
+	if a.regex != nil {
+		return a.regex.MatchString(from)
+	}
+	at := strings.LastIndex(from, "@")
+	return at >= 0 && strings.EqualFold(from[at+1:], a.domain)
+}
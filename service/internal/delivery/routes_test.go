@@ -0,0 +1,85 @@
+package delivery
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/config"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/logging"
+)
+
+func TestEmailProvider_RoutesSenders(t *testing.T) {
+	servers := map[string]*mockSMTP{}
+	account := func(name string, edit func(*config.EmailAccountConfig)) config.EmailAccountConfig {
+		servers[name] = startMockSMTP(t)
+		acc := testAccount("", servers[name].Port)
+		edit(&acc)
+		return acc
+	}
+	provider := NewEmailProvider(config.NewStaticStore(&config.Config{
+		EmailAccounts: []config.EmailAccountConfig{
+			account("regex", func(a *config.EmailAccountConfig) { a.AddressRegex = `noreply\+.*@example\.com` }),
+			account("domain", func(a *config.EmailAccountConfig) {
+				a.Address = "*@example.com"
+				a.EnvelopeFrom = "bounces@example.com"
+			}),
+			account("exact", func(a *config.EmailAccountConfig) { a.Address = "Support@example.com" }),
+			account("default", func(a *config.EmailAccountConfig) { a.Default = true }),
+		},
+	}), logging.Discard())
+
+	tests := []struct {
+		from     string
+		account  string
+		envelope string
+	}{
+		{"support@example.com", "exact", "support@example.com"},
+		{"noreply+123@example.com", "regex", "noreply+123@example.com"},
+		{"news@EXAMPLE.com", "domain", "bounces@example.com"},
+		{"noreply+123@example.com.evil", "default", "noreply+123@example.com.evil"},
+		{"someone@other.org", "default", "someone@other.org"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from, func(t *testing.T) {
+			before := len(servers[tt.account].Senders())
+			if _, err := provider.Send(context.Background(), testEmail(tt.from, "Hi", "Body", "r@example.com")); err != nil {
+				t.Fatalf("Send failed: %v", err)
+			}
+			senders := servers[tt.account].Senders()
+			if len(senders) != before+1 || senders[len(senders)-1] != tt.envelope {
+				t.Errorf("Expected %s to send with envelope sender %s, got %v", tt.account, tt.envelope, senders)
+			}
+		})
+	}
+}
+
+func TestEmailProvider_NoDefaultRejectsUnknownSender(t *testing.T) {
+	server := startMockSMTP(t)
+	provider := NewEmailProvider(config.NewStaticStore(&config.Config{
+		EmailAccounts: []config.EmailAccountConfig{testAccount("*@example.com", server.Port)},
+	}), logging.Discard())
+
+	if _, err := provider.Send(context.Background(), testEmail("a@other.org", "Hi", "Body", "r@example.com")); err == nil {
+		t.Fatal("Expected a sender outside the domain to be rejected")
+	}
+	if got := server.Senders(); len(got) != 0 {
+		t.Errorf("Expected nothing to be sent, got %v", got)
+	}
+}
+
+func TestEmailProvider_VERP(t *testing.T) {
+	server := startMockSMTP(t)
+	acc := testAccount("news@example.com", server.Port)
+	acc.EnvelopeFrom = "bounces@example.com"
+	acc.VERP = true
+	provider := NewEmailProvider(config.NewStaticStore(&config.Config{EmailAccounts: []config.EmailAccountConfig{acc}}), logging.Discard())
+
+	email := testEmail("news@example.com", "Hi", "Body", "r@example.com")
+	email.MessageID = "abc.123@example.com"
+	if _, err := provider.Send(context.Background(), email); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if got := server.Senders(); len(got) != 1 || got[0] != "bounces+abc.123@example.com" {
+		t.Errorf("Expected the message ID in the envelope sender, got %v", got)
+	}
+}
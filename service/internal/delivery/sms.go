@@ -1,54 +1,120 @@
 package delivery
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync/atomic"
 
 	"github.com/Low-Stack-Technologies/message-delivery-service/internal/config"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/health"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/tracing"
+	semconv "go.opentelemetry.io/otel/semconv/v1.43.0"
 )
 
+// elksAPI is the base URL of the 46elks REST API.
+const elksAPI = "https://api.46elks.com/a1"
+
 type SmsProvider struct {
-	config config.FortySixElksConfig
+	logger *slog.Logger
+	config atomic.Pointer[config.FortySixElksConfig]
+}
+
+// NewSmsProvider configures the provider from the store's active config and
+// reconfigures it whenever a new config is published.
+func NewSmsProvider(store *config.Store, logger *slog.Logger) *SmsProvider {
+	p := &SmsProvider{logger: logger}
+	p.Reconfigure(store.Get())
+	store.Subscribe(p.Reconfigure)
+	return p
 }
 
-func NewSmsProvider(cfg *config.Config) *SmsProvider {
-	return &SmsProvider{config: cfg.Sms.FortySixElks}
+// Reconfigure swaps in the 46elks credentials from cfg. Sends already in
+// progress keep using the credentials they started with.
+func (p *SmsProvider) Reconfigure(cfg *config.Config) {
+	elks := cfg.Sms.FortySixElks
+	p.config.Store(&elks)
 }
 
-func (p *SmsProvider) Send(from string, to []string, body string) error {
+func (p *SmsProvider) Send(ctx context.Context, from string, to []string, body string) error {
+	creds := p.config.Load()
+
 	// 46elks implementation
-	apiURL := "https://api.46elks.com/a1/sms"
+	apiURL := elksAPI + "/sms"
 
-	config.DebugLog("[DEBUG] SMS Delivery - Sending to %d recipients via 46elks", len(to))
+	p.logger.DebugContext(ctx, "SMS delivery via 46elks", "recipients", len(to))
 	for _, recipient := range to {
-		config.DebugLog("[DEBUG] SMS Delivery - Recipient: %s", recipient)
-		data := url.Values{}
-		data.Set("from", from)
-		data.Set("to", recipient)
-		data.Set("message", body)
-
-		req, err := http.NewRequest("POST", apiURL, strings.NewReader(data.Encode()))
-		if err != nil {
+		if err := p.sendOne(ctx, apiURL, creds, from, recipient, body); err != nil {
 			return err
 		}
+	}
 
-		req.SetBasicAuth(p.config.Username, p.config.Password)
-		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	return nil
+}
 
-		resp, err := http.DefaultClient.Do(req)
-		if err != nil {
-			return err
-		}
-		defer resp.Body.Close()
+// Probes checks that the 46elks API is reachable and accepts the configured
+// credentials by fetching the account details. Nothing is probed when 46elks
+// is not configured.
+func (p *SmsProvider) Probes() []health.Probe {
+	creds := p.config.Load()
+	if creds.Username == "" {
+		return nil
+	}
+	return []health.Probe{{
+		Name: "sms:46elks",
+		Kind: "sms",
+		Check: func(ctx context.Context) error {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, elksAPI+"/me", nil)
+			if err != nil {
+				return err
+			}
+			req.SetBasicAuth(creds.Username, creds.Password.Value())
 
-		if resp.StatusCode >= 400 {
-			config.DebugLog("[DEBUG] SMS Delivery Failed - 46elks error: %s", resp.Status)
-			return fmt.Errorf("46elks API error: %s", resp.Status)
-		}
-		config.DebugLog("[DEBUG] SMS Delivery Success - Sent to %s", recipient)
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				return err
+			}
+			resp.Body.Close()
+			if resp.StatusCode >= 400 {
+				return fmt.Errorf("46elks API error: %s", resp.Status)
+			}
+			return nil
+		},
+	}}
+}
+
+// sendOne posts a single message to the 46elks API in its own span.
+func (p *SmsProvider) sendOne(ctx context.Context, apiURL string, creds *config.FortySixElksConfig, from, recipient, body string) (err error) {
+	ctx, span := tracing.Start(ctx, "46elks send", semconv.HTTPRequestMethodPost)
+	defer func() { tracing.End(span, err) }()
+
+	data := url.Values{}
+	data.Set("from", from)
+	data.Set("to", recipient)
+	data.Set("message", body)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, strings.NewReader(data.Encode()))
+	if err != nil {
+		return err
 	}
 
+	req.SetBasicAuth(creds.Username, creds.Password.Value())
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+
+	if resp.StatusCode >= 400 {
+		p.logger.DebugContext(ctx, "SMS delivery failed", "to", recipient, "status", resp.Status)
+		return fmt.Errorf("46elks API error: %s", resp.Status)
+	}
+	p.logger.DebugContext(ctx, "SMS delivered", "to", recipient)
 	return nil
 }
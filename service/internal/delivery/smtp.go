@@ -0,0 +1,162 @@
+package delivery
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/config"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// connectSMTP opens an authenticated session with the account's server,
+// securing it according to the account's TLS mode. tokens supplies the
+// XOAUTH2 token when the account uses OAuth2, and is nil otherwise. conn is
+// the underlying connection, for setting deadlines. The caller must close
+// the client.
+func connectSMTP(ctx context.Context, cfg config.SMTPConfig, tokens *oauthTokens) (client *smtp.Client, conn net.Conn, err error) {
+	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+	mode := cfg.TLSMode()
+	var tlsConfig *tls.Config
+	if mode != config.SMTPTLSNone {
+		if tlsConfig, err = smtpTLSConfig(cfg); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// 1. Connect
+	var d net.Dialer
+	err = step(ctx, "smtp dial", func() (err error) {
+		conn, err = d.DialContext(ctx, "tcp", addr)
+		return err
+	}, attribute.String("server.address", cfg.Host), attribute.Int("server.port", cfg.Port))
+	if err != nil {
+		return nil, nil, err
+	}
+	if mode == config.SMTPTLSImplicit {
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err := step(ctx, "smtp tls", func() error { return tlsConn.HandshakeContext(ctx) }); err != nil {
+			conn.Close()
+			return nil, nil, err
+		}
+		conn = tlsConn
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	client, err = smtp.NewClient(conn, cfg.Host)
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	if err := negotiate(ctx, client, cfg, mode, tlsConfig, tokens); err != nil {
+		client.Close()
+		return nil, nil, err
+	}
+	return client, conn, nil
+}
+
+// negotiate greets the server, upgrades to TLS as the mode requires and
+// authenticates.
+func negotiate(ctx context.Context, client *smtp.Client, cfg config.SMTPConfig, mode string, tlsConfig *tls.Config, tokens *oauthTokens) error {
+	// 1. Greet
+	if err := step(ctx, "smtp ehlo", func() error { return client.Hello("localhost") }); err != nil {
+		return err
+	}
+
+	// 2. Upgrade
+	if mode == config.SMTPTLSStartTLS || mode == config.SMTPTLSStartTLSRequired {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := step(ctx, "smtp starttls", func() error { return client.StartTLS(tlsConfig) }); err != nil {
+				return err
+			}
+		} else if mode == config.SMTPTLSStartTLSRequired {
+			return errors.New("smtp: server does not offer STARTTLS")
+		}
+	}
+
+	// 3. Authenticate
+	mech := cfg.AuthMechanism()
+	if mech == config.SMTPAuthNone {
+		return nil
+	}
+	ok, offered := client.Extension("AUTH")
+	if !ok {
+		// Without an explicit mechanism, servers that take mail without
+		// authentication (e.g. internal relays) are accepted as before.
+		if cfg.Auth == "" {
+			return nil
+		}
+		return errors.New("smtp: server does not offer AUTH")
+	}
+	if !offersMechanism(offered, mech) {
+		return fmt.Errorf("smtp: server does not offer AUTH %s (offers %s)", mech, offered)
+	}
+	secret := cfg.Password.Value()
+	if mech == config.SMTPAuthXOAuth2 && tokens != nil {
+		token, err := tokens.Token(ctx)
+		if err != nil {
+			return err
+		}
+		secret = token
+	}
+	auth, err := smtpAuth(cfg, mech, secret)
+	if err != nil {
+		return err
+	}
+	err = step(ctx, "smtp auth", func() error { return client.Auth(auth) }, attribute.String("mds.smtp.auth", mech))
+	var reply *textproto.Error
+	if tokens != nil && errors.As(err, &reply) {
+		// The token may have been revoked; fetch a new one next time.
+		tokens.Invalidate()
+	}
+	return err
+}
+
+// smtpTLSConfig verifies the server against the account's CA bundle, if
+// any, and server name.
+func smtpTLSConfig(cfg config.SMTPConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{ServerName: cfg.Host}
+	if cfg.ServerName != "" {
+		tlsConfig.ServerName = cfg.ServerName
+	}
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read SMTP CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in SMTP CA file %s", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	return tlsConfig, nil
+}
+
+func offersMechanism(offered, mech string) bool {
+	for _, m := range strings.Fields(offered) {
+		if strings.EqualFold(m, mech) {
+			return true
+		}
+	}
+	return false
+}
+
+// step runs fn in a child span of ctx named name.
+func step(ctx context.Context, name string, fn func() error, attrs ...attribute.KeyValue) error {
+	_, span := tracing.Start(ctx, name, attrs...)
+	err := fn()
+	tracing.End(span, err)
+	return err
+}
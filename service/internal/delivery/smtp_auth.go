@@ -0,0 +1,85 @@
+package delivery
+
+import (
+	"errors"
+	"fmt"
+	"net/smtp"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/config"
+)
+
+// smtpAuth returns the client side of the account's SASL mechanism, with
+// password as the password or, for XOAUTH2, the access token. Mechanisms
+// that reveal it refuse to run over an unencrypted connection to anything
+// but localhost, like smtp.PlainAuth does.
+func smtpAuth(cfg config.SMTPConfig, mech, password string) (smtp.Auth, error) {
+	switch mech {
+	case config.SMTPAuthPlain:
+		return smtp.PlainAuth("", cfg.Username, password, cfg.Host), nil
+	case config.SMTPAuthLogin:
+		return &loginAuth{username: cfg.Username, password: password, host: cfg.Host}, nil
+	case config.SMTPAuthCRAMMD5:
+		return smtp.CRAMMD5Auth(cfg.Username, password), nil
+	case config.SMTPAuthXOAuth2:
+		return &xoauth2Auth{username: cfg.Username, token: password, host: cfg.Host}, nil
+	}
+	return nil, fmt.Errorf("smtp: unsupported auth mechanism %s", mech)
+}
+
+// checkEncrypted guards mechanisms that send credentials in the clear.
+func checkEncrypted(server *smtp.ServerInfo, host string) error {
+	if server.Name != host {
+		return errors.New("smtp: wrong host name")
+	}
+	if !server.TLS && host != "localhost" && host != "127.0.0.1" && host != "::1" {
+		return errors.New("smtp: unencrypted connection")
+	}
+	return nil
+}
+
+// loginAuth implements the LOGIN mechanism: the server prompts for the
+// username and the password in turn.
+type loginAuth struct {
+	username, password, host string
+}
+
+func (a *loginAuth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	if err := checkEncrypted(server, a.host); err != nil {
+		return "", nil, err
+	}
+	return "LOGIN", nil, nil
+}
+
+func (a *loginAuth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if !more {
+		return nil, nil
+	}
+	switch string(fromServer) {
+	case "Username:", "User Name", "Username":
+		return []byte(a.username), nil
+	case "Password:", "Password":
+		return []byte(a.password), nil
+	}
+	return nil, fmt.Errorf("smtp: unexpected LOGIN challenge %q", fromServer)
+}
+
+// xoauth2Auth implements XOAUTH2 as used by Google and Microsoft: the
+// initial response carries the user and a bearer token. On failure the
+// server sends a JSON error and expects an empty reply.
+type xoauth2Auth struct {
+	username, token, host string
+}
+
+func (a *xoauth2Auth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	if err := checkEncrypted(server, a.host); err != nil {
+		return "", nil, err
+	}
+	return "XOAUTH2", []byte("user=" + a.username + "\x01auth=Bearer " + a.token + "\x01\x01"), nil
+}
+
+func (a *xoauth2Auth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if more {
+		return []byte{}, nil
+	}
+	return nil, nil
+}
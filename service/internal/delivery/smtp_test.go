@@ -0,0 +1,182 @@
+package delivery
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/config"
+)
+
+// testTLS returns a server config with a self-signed certificate for
+// smtp.test, and a CA file trusting it.
+func testTLS(t *testing.T) (*tls.Config, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		DNSNames:              []string{"smtp.test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("Failed to write CA file: %v", err)
+	}
+	cert := tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+	return &tls.Config{Certificates: []tls.Certificate{cert}}, caFile
+}
+
+func TestConnectSMTP_TLS(t *testing.T) {
+	serverTLS, caFile := testTLS(t)
+
+	tests := []struct {
+		name     string
+		server   string // "implicit", "starttls" or "plain"
+		tls      string
+		caFile   string
+		wantErr  bool
+		commands string
+	}{
+		{"implicit", "implicit", config.SMTPTLSImplicit, caFile, false, "EHLO AUTH QUIT"},
+		{"starttls offered", "starttls", config.SMTPTLSStartTLS, caFile, false, "EHLO STARTTLS EHLO AUTH QUIT"},
+		{"starttls not offered", "plain", config.SMTPTLSStartTLS, "", false, "EHLO AUTH QUIT"},
+		{"starttls required", "plain", config.SMTPTLSStartTLSRequired, "", true, "EHLO"},
+		{"none", "starttls", config.SMTPTLSNone, "", false, "EHLO AUTH QUIT"},
+		{"untrusted certificate", "starttls", config.SMTPTLSStartTLS, "", true, "EHLO STARTTLS"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := startMockSMTP(t)
+			if tt.server != "plain" {
+				server.UseTLS(serverTLS, tt.server == "implicit")
+			}
+			cfg := testAccount("test@example.com", server.Port).SMTP
+			cfg.TLS = tt.tls
+			cfg.CAFile = tt.caFile
+			cfg.ServerName = "smtp.test"
+
+			client, _, err := connectSMTP(context.Background(), cfg, nil)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Expected connect to fail")
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("Connect failed: %v", err)
+				}
+				client.Quit()
+			}
+			if got := strings.Join(server.Commands(), " "); got != tt.commands {
+				t.Errorf("Expected commands %q, got %q", tt.commands, got)
+			}
+		})
+	}
+}
+
+func TestConnectSMTP_ServerName(t *testing.T) {
+	serverTLS, caFile := testTLS(t)
+	server := startMockSMTP(t)
+	server.UseTLS(serverTLS, true)
+
+	cfg := testAccount("test@example.com", server.Port).SMTP
+	cfg.CAFile = caFile
+	cfg.TLS = config.SMTPTLSImplicit
+	if _, _, err := connectSMTP(context.Background(), cfg, nil); err == nil {
+		t.Fatal("Expected the certificate to be rejected for 127.0.0.1")
+	}
+
+	cfg.ServerName = "smtp.test"
+	client, _, err := connectSMTP(context.Background(), cfg, nil)
+	if err != nil {
+		t.Fatalf("Connect with server name failed: %v", err)
+	}
+	client.Quit()
+}
+
+func TestConnectSMTP_Auth(t *testing.T) {
+	mac := hmac.New(md5.New, []byte("password"))
+	mac.Write([]byte("<1896.697170952@mock.smtp.server>"))
+	cramMD5 := "CRAM-MD5 user " + hex.EncodeToString(mac.Sum(nil))
+
+	tests := []struct {
+		name    string
+		offered string
+		auth    string
+		wantErr bool
+		want    []string
+	}{
+		{"default plain", "PLAIN LOGIN", "", false, []string{"PLAIN \x00user\x00password"}},
+		{"login", "PLAIN LOGIN", "login", false, []string{"LOGIN user password"}},
+		{"cram-md5", "CRAM-MD5", "CRAM-MD5", false, []string{cramMD5}},
+		{"xoauth2", "XOAUTH2", "XOAUTH2", false, []string{"XOAUTH2 user=user\x01auth=Bearer password\x01\x01"}},
+		{"none", "PLAIN", "none", false, nil},
+		{"not offered", "PLAIN", "CRAM-MD5", true, nil},
+		{"no auth extension", "", "", false, nil},
+		{"no auth extension, explicit", "", "PLAIN", true, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := startMockSMTP(t)
+			server.OfferAuth(tt.offered)
+			cfg := testAccount("test@example.com", server.Port).SMTP
+			cfg.Auth = tt.auth
+
+			client, _, err := connectSMTP(context.Background(), cfg, nil)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Expected connect to fail")
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("Connect failed: %v", err)
+				}
+				client.Quit()
+			}
+			if got := server.Auths(); !slices.Equal(got, tt.want) {
+				t.Errorf("Expected auth %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSMTPAuth_RefusesUnencrypted(t *testing.T) {
+	cfg := config.SMTPConfig{Host: "smtp.example.com", Username: "user", Password: "password"}
+	for _, mech := range []string{config.SMTPAuthPlain, config.SMTPAuthLogin, config.SMTPAuthXOAuth2} {
+		auth, err := smtpAuth(cfg, mech, "password")
+		if err != nil {
+			t.Fatalf("Failed to create %s auth: %v", mech, err)
+		}
+		if _, _, err := auth.Start(&smtp.ServerInfo{Name: "smtp.example.com", TLS: false}); err == nil {
+			t.Errorf("Expected %s to refuse an unencrypted connection", mech)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/apierror"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/auth"
+	"github.com/Low-Stack-Technologies/message-delivery-service/pkg/api"
+)
+
+func (h *Handler) GetAdminConfig(w http.ResponseWriter, r *http.Request, params api.GetAdminConfigParams) {
+	// 1. Authorize
+	service, ok := auth.ServiceFromContext(r.Context())
+	if !ok || !service.Admin {
+		apierror.Write(w, http.StatusForbidden, apierror.CodeForbidden, "Admin access required")
+		return
+	}
+
+	// 2. Collect Status
+	cfg := h.store.Get()
+	redacted, err := cfg.RedactedMap()
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "Failed to render config", "error", err)
+		apierror.Write(w, http.StatusInternalServerError, apierror.CodeInternal, "Failed to render config")
+		return
+	}
+
+	status := h.store.Status()
+	resp := api.ConfigStatus{
+		Path: status.Path,
+		Version: api.ConfigVersion{
+			Hash:     cfg.Version().Hash,
+			LoadedAt: cfg.Version().LoadedAt,
+		},
+		Reloads:       status.Reloads,
+		FailedReloads: status.FailedReloads,
+		Events:        make([]api.ConfigReloadEvent, 0, len(status.Events)),
+		Config:        redacted,
+	}
+	if status.LastError != "" {
+		resp.LastError = &status.LastError
+		resp.LastErrorAt = &status.LastErrorAt
+	}
+	for _, e := range status.Events {
+		event := api.ConfigReloadEvent{At: e.Time}
+		if e.Hash != "" {
+			event.Hash = &e.Hash
+		}
+		if e.Error != "" {
+			event.Error = &e.Error
+		}
+		resp.Events = append(resp.Events, event)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/apierror"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/bounce"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/tracking"
+	"github.com/Low-Stack-Technologies/message-delivery-service/pkg/api"
+)
+
+func (h *Handler) PostWebhooksBounces(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cfg := h.store.Get()
+
+	// 1. Authorize
+	secret := cfg.Bounces.WebhookSecret.Value()
+	if secret == "" {
+		apierror.NotFound(w, r)
+		return
+	}
+	if !webhookAuthorized(r, secret) {
+		h.logger.DebugContext(ctx, "Bounce webhook rejected: invalid secret")
+		apierror.Write(w, http.StatusUnauthorized, apierror.CodeInvalidAuthHeader, "Invalid webhook secret")
+		return
+	}
+
+	// 2. Read Report
+	limit := cfg.MaxBodyBytes(r.URL.Path)
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, limit))
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			apierror.Write(w, http.StatusRequestEntityTooLarge, apierror.CodePayloadTooLarge, "Request body too large",
+				fmt.Sprintf("body: must not exceed %d bytes", limit))
+			return
+		}
+		apierror.Write(w, http.StatusBadRequest, apierror.CodeInvalidBody, "Failed to read request body")
+		return
+	}
+	var events []bounce.Event
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "application/json" {
+		var n api.BounceNotification
+		if err := json.Unmarshal(body, &n); err != nil {
+			apierror.Write(w, http.StatusBadRequest, apierror.CodeInvalidBody, "Invalid request body", apierror.DecodeDetails(err)...)
+			return
+		}
+		event, err := notificationEvent(n)
+		if err != nil {
+			apierror.Write(w, http.StatusBadRequest, apierror.CodeInvalidBody, "Invalid request body", err.Error())
+			return
+		}
+		events = append(events, event)
+	} else if events, err = bounce.Parse(body); err != nil {
+		h.logger.DebugContext(ctx, "Bounce webhook rejected: unreadable report", "error", err)
+		apierror.Write(w, http.StatusBadRequest, apierror.CodeInvalidBody, "Not a delivery status notification or feedback report")
+		return
+	}
+
+	// 3. Process
+	if err := h.bounces.Process(ctx, events); err != nil {
+		h.logger.ErrorContext(ctx, "Failed to process bounce", "error", err)
+		apierror.Write(w, http.StatusInternalServerError, apierror.CodeInternal, "Failed to process bounce")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(api.SuccessResponse{
+		Success: true,
+		Message: "Report processed",
+	})
+}
+
+// webhookAuthorized reports whether the request carries secret as a bearer
+// token or basic-auth password.
+func webhookAuthorized(r *http.Request, secret string) bool {
+	given, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		_, given, ok = r.BasicAuth()
+	}
+	return ok && subtle.ConstantTimeCompare([]byte(given), []byte(secret)) == 1
+}
+
+// notificationEvent converts a JSON notification to the event a report with
+// the same outcome would give.
+func notificationEvent(n api.BounceNotification) (bounce.Event, error) {
+	if n.Recipient == "" {
+		return bounce.Event{}, errors.New("recipient: is required")
+	}
+	e := bounce.Event{Recipient: string(n.Recipient)}
+	if n.MessageId != nil {
+		e.MessageID = strings.Trim(*n.MessageId, "<>")
+	}
+	if n.Diagnostic != nil {
+		e.Detail = *n.Diagnostic
+	}
+	switch n.Type {
+	case api.Bounce:
+		e.Status = tracking.StatusBounced
+		e.Suppress = n.BounceType == nil || *n.BounceType == api.Hard
+	case api.Complaint:
+		e.Status, e.Suppress = tracking.StatusComplained, true
+	case api.Delay:
+		e.Status = tracking.StatusDeferred
+	case api.Delivery:
+		e.Status = tracking.StatusDelivered
+	default:
+		return bounce.Event{}, fmt.Errorf("type: unknown value %q (expected bounce, complaint, delay or delivery)", n.Type)
+	}
+	return e, nil
+}
@@ -1,170 +1,369 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
+	"net/mail"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/apierror"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/bounce"
 	"github.com/Low-Stack-Technologies/message-delivery-service/internal/config"
 	"github.com/Low-Stack-Technologies/message-delivery-service/internal/delivery"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/health"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/message"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/schedule"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/suppression"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/tracing"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/tracking"
 	"github.com/Low-Stack-Technologies/message-delivery-service/pkg/api"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type Handler struct {
-	email *delivery.EmailProvider
-	sms   *delivery.SmsProvider
+	store        *config.Store
+	email        delivery.EmailSender
+	sms          delivery.SmsSender
+	health       *health.Checker
+	messages     *tracking.Store
+	suppressions *suppression.List
+	scheduled    *schedule.Queue
+	bounces      *bounce.Processor
+	logger       *slog.Logger
 }
 
-func NewHandler(email *delivery.EmailProvider, sms *delivery.SmsProvider) *Handler {
+func NewHandler(store *config.Store, email delivery.EmailSender, sms delivery.SmsSender, checker *health.Checker,
+	messages *tracking.Store, suppressions *suppression.List, scheduled *schedule.Queue, bounces *bounce.Processor,
+	logger *slog.Logger) *Handler {
 	return &Handler{
-		email: email,
-		sms:   sms,
+		store:        store,
+		email:        email,
+		sms:          sms,
+		health:       checker,
+		messages:     messages,
+		suppressions: suppressions,
+		scheduled:    scheduled,
+		bounces:      bounces,
+		logger:       logger,
 	}
 }
 
-func (h *Handler) GetHealth(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"status":    "ok",
-		"timestamp": time.Now().Format(time.RFC3339),
-	})
-}
-
 func (h *Handler) PostV3Email(w http.ResponseWriter, r *http.Request, params api.PostV3EmailParams) {
+	ctx, span := tracing.Start(r.Context(), "handle email")
+	defer span.End()
 	var req api.EmailRequest
-	config.DebugLog("[DEBUG] PostV3Email - Decoding request body...")
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		config.DebugLog("[DEBUG] PostV3Email - Decode error: %v", err)
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		h.logger.DebugContext(ctx, "Invalid email request body", "error", err)
+		apierror.Write(w, http.StatusBadRequest, apierror.CodeInvalidBody, "Invalid request body", apierror.DecodeDetails(err)...)
+		return
+	}
+	scope, ok := requestScope(w, req.Scope)
+	if !ok {
+		return
+	}
+	sendAt, ok := requestSendAt(w, req.SendAt)
+	if !ok {
 		return
 	}
 
 	// 1. Extract Recipients
-	var addresses []string
+	var recipients []mail.Address
 	if addr, err := req.To.AsEmailRequestTo0(); err == nil {
-		addresses = append(addresses, string(addr))
+		recipients = append(recipients, mail.Address{Address: string(addr)})
 	} else if contact, err := req.To.AsEmailContact(); err == nil {
-		addresses = append(addresses, string(contact.Address))
+		recipients = append(recipients, contactAddress(contact))
 	} else if multi, err := req.To.AsEmailRequestTo2(); err == nil {
 		for _, item := range multi {
 			if a, err := item.AsEmailRequestTo20(); err == nil {
-				addresses = append(addresses, string(a))
+				recipients = append(recipients, mail.Address{Address: string(a)})
 			} else if c, err := item.AsEmailContact(); err == nil {
-				addresses = append(addresses, string(c.Address))
+				recipients = append(recipients, contactAddress(c))
 			}
 		}
 	}
 
-	if len(addresses) == 0 {
-		config.DebugLog("[DEBUG] PostV3Email - No recipients extracted from: %+v", req.To)
-		http.Error(w, "No recipients specified", http.StatusBadRequest)
+	if len(recipients) == 0 {
+		h.logger.DebugContext(ctx, "No email recipients in request")
+		apierror.Write(w, http.StatusBadRequest, apierror.CodeNoRecipients, "No recipients specified",
+			"to: expected an email address, a contact or a list of them")
 		return
 	}
-	config.DebugLog("[DEBUG] PostV3Email - Recipients: %v", addresses)
+	email := &message.Email{From: contactAddress(req.From), To: recipients, Subject: req.Subject}
+	h.logger.DebugContext(ctx, "Email request accepted", "from", email.From.Address, "to", email.Recipients())
 
 	// 2. Extract Content
-	var body string
-	var isHTML bool
 	if req.Content != nil {
-		if c0, err := req.Content.AsEmailRequestContent0(); err == nil {
-			body = c0.Body
+		// Any object decodes as inline content, so check for a template first.
+		if c1, err := req.Content.AsEmailRequestContent1(); err == nil && c1.Template.Name != "" {
+			email.Body = renderTemplate(ctx, c1.Template.Name, c1.Template.Data)
+		} else if c0, err := req.Content.AsEmailRequestContent0(); err == nil {
+			email.Body = c0.Body
 			if c0.IsHtml != nil {
-				isHTML = *c0.IsHtml
+				email.HTML = *c0.IsHtml
 			}
-		} else if c1, err := req.Content.AsEmailRequestContent1(); err == nil {
-			// In a real app, we'd render the template here
-			body = fmt.Sprintf("Template: %s, Data: %v", c1.Template.Name, c1.Template.Data)
 		}
 	}
+	if err := email.Validate(); err != nil {
+		h.logger.DebugContext(ctx, "Invalid email headers", "error", err)
+		apierror.Write(w, http.StatusBadRequest, apierror.CodeInvalidBody, "Invalid header value", strings.Split(err.Error(), "\n")...)
+		return
+	}
+	messageID, err := message.NewMessageID(email.From.Address)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to generate Message-ID", "error", err)
+		apierror.Write(w, http.StatusInternalServerError, apierror.CodeInternal, "Failed to generate Message-ID")
+		return
+	}
+	email.MessageID = messageID
 
-	// 3. Send
-	if err := h.email.Send(string(req.From.Address), addresses, req.Subject, body, isHTML); err != nil {
-		log.Printf("Email send error: %v", err)
-		h.sendError(w, "DELIVERY_FAILED", err.Error(), http.StatusInternalServerError)
+	// 3. Schedule
+	if !sendAt.IsZero() {
+		job := &schedule.Job{ID: email.MessageID, Channel: "email", Scope: scope, SendAt: sendAt, Email: email}
+		statuses, err := h.schedule(ctx, email.From.Address, job)
+		if err != nil {
+			h.logger.ErrorContext(ctx, "Failed to schedule email", "error", err)
+			apierror.Write(w, http.StatusInternalServerError, apierror.CodeInternal, "Failed to schedule email")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(api.EmailSuccessResponse{
+			Success:    true,
+			Message:    "Email scheduled for delivery",
+			MessageId:  job.ID,
+			SendAt:     &sendAt,
+			Recipients: recipientStatuses(statuses),
+		})
 		return
 	}
 
+	// 4. Filter Suppressed Recipients
+	statuses, err := h.filterEmail(ctx, email, scope)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to check suppressions", "error", err)
+		apierror.Write(w, http.StatusInternalServerError, apierror.CodeInternal, "Failed to check suppressions")
+		return
+	}
+
+	// 5. Send
+	result := "Email accepted for delivery"
+	if len(email.To) == 0 {
+		result = "No email sent: all recipients are suppressed"
+	} else if err := queue(ctx, "email", len(email.To), func(ctx context.Context) (err error) {
+		messageID, err = h.email.Send(ctx, email)
+		return err
+	}); err != nil {
+		h.logger.ErrorContext(ctx, "Email delivery failed", "error", err)
+		tracing.Fail(span, err)
+		apierror.Write(w, http.StatusInternalServerError, apierror.CodeDeliveryFailed, err.Error())
+		return
+	}
+
+	// 6. Track
+	h.recordMessage(ctx, &tracking.Message{ID: messageID, Channel: "email", From: email.From.Address, Recipients: statuses})
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusAccepted)
-	json.NewEncoder(w).Encode(api.SuccessResponse{
-		Success: true,
-		Message: "Email accepted for delivery",
+	json.NewEncoder(w).Encode(api.EmailSuccessResponse{
+		Success:    true,
+		Message:    result,
+		MessageId:  messageID,
+		Recipients: recipientStatuses(statuses),
 	})
 }
 
-func (h *Handler) sendError(w http.ResponseWriter, code, message string, status int) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(api.ErrorResponse{
-		Success: false,
-		Error: struct {
-			Code    string    `json:"code"`
-			Details *[]string `json:"details,omitempty"`
-			Message string    `json:"message"`
-		}{
-			Code:    code,
-			Message: message,
-		},
-	})
+// filterEmail drops suppressed recipients from email.To and returns the
+// status of each original recipient. Single-recipient emails with a scope get
+// a one-click unsubscribe link when links are configured.
+func (h *Handler) filterEmail(ctx context.Context, email *message.Email, scope string) ([]tracking.Recipient, error) {
+	statuses, err := h.filterSuppressed(ctx, email.Recipients(), scope)
+	if err != nil {
+		return nil, err
+	}
+	kept := email.To[:0:0]
+	for i, to := range email.To {
+		if statuses[i].Status == tracking.StatusSent {
+			kept = append(kept, to)
+		}
+	}
+	email.To = kept
+	// The header names a single address, so only single-recipient emails get one.
+	if cfg := h.store.Get().Unsubscribe; cfg.Enabled() && scope != "" && len(email.To) == 1 {
+		token, err := suppression.UnsubscribeToken(cfg.Secret.Value(), email.To[0].Address, scope)
+		if err != nil {
+			return nil, err
+		}
+		email.ListUnsubscribe = strings.TrimSuffix(cfg.BaseURL, "/") + "/unsubscribe/" + token
+	}
+	return statuses, nil
+}
+
+// contactAddress converts an API contact to a mail address.
+func contactAddress(c api.EmailContact) mail.Address {
+	addr := mail.Address{Address: string(c.Address)}
+	if c.Name != nil {
+		addr.Name = *c.Name
+	}
+	return addr
 }
 
 func (h *Handler) PostV3Sms(w http.ResponseWriter, r *http.Request, params api.PostV3SmsParams) {
+	ctx, span := tracing.Start(r.Context(), "handle sms")
+	defer span.End()
 	var req api.SmsRequest
-	config.DebugLog("[DEBUG] PostV3Sms - Decoding request body...")
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		config.DebugLog("[DEBUG] PostV3Sms - Decode error: %v", err)
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		h.logger.DebugContext(ctx, "Invalid SMS request body", "error", err)
+		apierror.Write(w, http.StatusBadRequest, apierror.CodeInvalidBody, "Invalid request body", apierror.DecodeDetails(err)...)
+		return
+	}
+	scope, ok := requestScope(w, req.Scope)
+	if !ok {
+		return
+	}
+	sendAt, ok := requestSendAt(w, req.SendAt)
+	if !ok {
 		return
 	}
 
 	// 1. Extract Recipients
 	var numbers []string
-	if single, err := req.To.AsSmsRecipient(); err == nil {
-		if s0, err := single.AsSmsRecipient0(); err == nil {
-			numbers = append(numbers, s0)
-		} else if s1, err := single.AsSmsRecipient1(); err == nil {
-			numbers = append(numbers, s1.Phone) // Simplification: assuming phone is already E.164 or handled by provider
-		}
-	} else if multi, err := req.To.AsSmsRequestTo1(); err == nil {
-		for _, item := range multi {
-			if s0, err := item.AsSmsRecipient0(); err == nil {
-				numbers = append(numbers, s0)
-			} else if s1, err := item.AsSmsRecipient1(); err == nil {
-				numbers = append(numbers, s1.Phone)
+	var zones [][]*time.Location // of each number, for quiet hours; nil when unknown
+	var problems []string
+	addRecipient := func(field string, r api.SmsRecipient) {
+		if s0, err := r.AsSmsRecipient0(); err == nil {
+			numbers, zones = append(numbers, s0), append(zones, nil)
+		} else if s1, err := r.AsSmsRecipient1(); err == nil {
+			locs, err := recipientLocations(s1)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s.timeZone: %v", field, err))
 			}
+			// Simplification: assuming phone is already E.164 or handled by provider
+			numbers, zones = append(numbers, s1.Phone), append(zones, locs)
 		}
 	}
+	// A single recipient decodes from any JSON, so check for a list first.
+	if multi, err := req.To.AsSmsRequestTo1(); err == nil {
+		for i, item := range multi {
+			addRecipient(fmt.Sprintf("to[%d]", i), item)
+		}
+	} else if single, err := req.To.AsSmsRecipient(); err == nil {
+		addRecipient("to", single)
+	}
 
 	if len(numbers) == 0 {
-		config.DebugLog("[DEBUG] PostV3Sms - No recipients extracted from: %+v", req.To)
-		http.Error(w, "No recipients specified", http.StatusBadRequest)
+		h.logger.DebugContext(ctx, "No SMS recipients in request")
+		apierror.Write(w, http.StatusBadRequest, apierror.CodeNoRecipients, "No recipients specified",
+			"to: expected a phone number, a recipient object or a list of them")
+		return
+	}
+	if len(problems) > 0 {
+		apierror.Write(w, http.StatusBadRequest, apierror.CodeInvalidBody, "Invalid request body", problems...)
 		return
 	}
-	config.DebugLog("[DEBUG] PostV3Sms - Recipients: %v", numbers)
+	h.logger.DebugContext(ctx, "SMS request accepted", "to", numbers)
 
 	// 2. Extract Content
 	var body string
 	if req.Content != nil {
-		if c0, err := req.Content.AsSmsRequestContent0(); err == nil {
+		if c1, err := req.Content.AsSmsRequestContent1(); err == nil && c1.Template.Name != "" {
+			body = renderTemplate(ctx, c1.Template.Name, c1.Template.Data)
+		} else if c0, err := req.Content.AsSmsRequestContent0(); err == nil {
 			body = c0.Body
-		} else if c1, err := req.Content.AsSmsRequestContent1(); err == nil {
-			body = fmt.Sprintf("Template: %s, Data: %v", c1.Template.Name, c1.Template.Data)
 		}
 	}
 
-	// 3. Send
-	if err := h.sms.Send(req.SenderName, numbers, body); err != nil {
-		log.Printf("SMS send error: %v", err)
-		h.sendError(w, "DELIVERY_FAILED", err.Error(), http.StatusInternalServerError)
+	// 3. Quiet Hours
+	times := h.quietHours(ctx, req.Class, sendAt, zones)
+
+	// 4. Schedule (when sendAt is set or quiet hours defer any recipient)
+	if slices.ContainsFunc(times, func(t time.Time) bool { return !t.IsZero() }) {
+		jobs := smsJobs(numbers, times, sendAt, schedule.SMS{Sender: req.SenderName, Body: body}, scope)
+		statuses, err := h.schedule(ctx, req.SenderName, jobs...)
+		if err != nil {
+			h.logger.ErrorContext(ctx, "Failed to schedule SMS", "error", err)
+			apierror.Write(w, http.StatusInternalServerError, apierror.CodeInternal, "Failed to schedule SMS")
+			return
+		}
+		resp := api.SmsSuccessResponse{
+			Success:    true,
+			Message:    "SMS scheduled for delivery",
+			MessageId:  &jobs[0].ID,
+			Recipients: recipientStatuses(statuses),
+		}
+		if len(jobs) == 1 {
+			resp.SendAt = &jobs[0].SendAt
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	// 5. Filter Suppressed Recipients
+	statuses, kept, err := h.filterSms(ctx, numbers, scope)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to check suppressions", "error", err)
+		apierror.Write(w, http.StatusInternalServerError, apierror.CodeInternal, "Failed to check suppressions")
+		return
+	}
+
+	// 6. Send
+	result := "SMS accepted for delivery"
+	if len(kept) == 0 {
+		result = "No SMS sent: all recipients are suppressed"
+	} else if err := queue(ctx, "sms", len(kept), func(ctx context.Context) error {
+		return h.sms.Send(ctx, req.SenderName, kept, body)
+	}); err != nil {
+		h.logger.ErrorContext(ctx, "SMS delivery failed", "error", err)
+		tracing.Fail(span, err)
+		apierror.Write(w, http.StatusInternalServerError, apierror.CodeDeliveryFailed, err.Error())
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusAccepted)
-	json.NewEncoder(w).Encode(api.SuccessResponse{
-		Success: true,
-		Message: "SMS accepted for delivery",
+	json.NewEncoder(w).Encode(api.SmsSuccessResponse{
+		Success:    true,
+		Message:    result,
+		Recipients: recipientStatuses(statuses),
 	})
 }
+
+// filterSms returns the status of each number and the numbers that are not
+// suppressed.
+func (h *Handler) filterSms(ctx context.Context, numbers []string, scope string) ([]tracking.Recipient, []string, error) {
+	statuses, err := h.filterSuppressed(ctx, numbers, scope)
+	if err != nil {
+		return nil, nil, err
+	}
+	var kept []string
+	for i, number := range numbers {
+		if statuses[i].Status == tracking.StatusSent {
+			kept = append(kept, number)
+		}
+	}
+	return statuses, kept, nil
+}
+
+// renderTemplate renders a named template with data. Templates are not
+// stored yet, so the result is a placeholder naming both.
+func renderTemplate(ctx context.Context, name string, data map[string]interface{}) string {
+	_, span := tracing.Start(ctx, "render template", attribute.String("mds.template", name))
+	defer span.End()
+	return fmt.Sprintf("Template: %s, Data: %v", name, data)
+}
+
+// queue hands a message to its channel's delivery queue and waits for the
+// outcome. Delivery currently happens inline, so the span covers the whole
+// provider interaction.
+func queue(ctx context.Context, channel string, recipients int, send func(context.Context) error) error {
+	ctx, span := tracing.Start(ctx, "queue "+channel, attribute.Int("mds.recipients", recipients))
+	err := send(ctx)
+	tracing.End(span, err)
+	return err
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/health"
+	"github.com/Low-Stack-Technologies/message-delivery-service/pkg/api"
+)
+
+// GetHealth is the legacy liveness endpoint.
+func (h *Handler) GetHealth(w http.ResponseWriter, r *http.Request) {
+	h.GetHealthLive(w, r)
+}
+
+func (h *Handler) GetHealthLive(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(api.LivenessStatus{
+		Status:    "ok",
+		Timestamp: time.Now(),
+	})
+}
+
+func (h *Handler) GetHealthReady(w http.ResponseWriter, r *http.Request) {
+	report := h.health.Report(r.Context())
+
+	resp := api.ReadinessReport{
+		Status:    api.Ready,
+		Timestamp: report.CheckedAt,
+		Checks:    make([]api.DependencyCheck, 0, len(report.Checks)),
+	}
+	status := http.StatusOK
+	if !report.Ready {
+		resp.Status = api.NotReady
+		status = http.StatusServiceUnavailable
+	}
+	for _, res := range report.Checks {
+		check := api.DependencyCheck{
+			Name:      res.Name,
+			Kind:      res.Kind,
+			Status:    api.DependencyCheckStatus(res.Status),
+			LatencyMs: float64(res.Latency.Microseconds()) / 1000,
+			CheckedAt: res.CheckedAt,
+		}
+		if res.Status == health.StatusDown {
+			check.Error = &res.Error
+		}
+		resp.Checks = append(resp.Checks, check)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/apierror"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/auth"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/schedule"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/tracking"
+	"github.com/Low-Stack-Technologies/message-delivery-service/pkg/api"
+)
+
+func (h *Handler) GetV3MessagesMessageId(w http.ResponseWriter, r *http.Request, messageId string, params api.GetV3MessagesMessageIdParams) {
+	msg, ok := h.ownMessage(w, r, messageId)
+	if !ok {
+		return
+	}
+
+	resp := api.MessageStatus{
+		Id:         msg.ID,
+		Channel:    api.MessageStatusChannel(msg.Channel),
+		CreatedAt:  msg.CreatedAt,
+		Recipients: recipientStatuses(msg.Recipients),
+	}
+	if msg.From != "" {
+		resp.From = &msg.From
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (h *Handler) DeleteV3MessagesMessageId(w http.ResponseWriter, r *http.Request, messageId string, params api.DeleteV3MessagesMessageIdParams) {
+	ctx := r.Context()
+	if _, ok := h.ownMessage(w, r, messageId); !ok {
+		return
+	}
+
+	// 1. Cancel
+	jobs, err := h.scheduled.Cancel(ctx, messageId)
+	if errors.Is(err, schedule.ErrNotFound) {
+		apierror.Write(w, http.StatusConflict, apierror.CodeNotScheduled, "Message has already been sent or cancelled")
+		return
+	}
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to cancel scheduled message", "message_id", messageId, "error", err)
+		apierror.Write(w, http.StatusInternalServerError, apierror.CodeInternal, "Failed to cancel scheduled message")
+		return
+	}
+	h.logger.InfoContext(ctx, "Scheduled message cancelled", "message_id", messageId)
+
+	// 2. Track (recipients already sent by other jobs keep their status)
+	for _, job := range jobs {
+		h.updateStatuses(ctx, messageId, jobStatuses(job, tracking.StatusCancelled, ""))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(api.SuccessResponse{
+		Success: true,
+		Message: "Scheduled message cancelled",
+	})
+}
+
+// ownMessage returns the tracked message with the given ID if the caller sent
+// it or is an admin, writing the error response otherwise. Other services'
+// messages are reported as missing.
+func (h *Handler) ownMessage(w http.ResponseWriter, r *http.Request, id string) (*tracking.Message, bool) {
+	ctx := r.Context()
+	msg, err := h.messages.Get(ctx, id)
+	if err != nil && !errors.Is(err, tracking.ErrNotFound) {
+		h.logger.ErrorContext(ctx, "Failed to read message status", "message_id", id, "error", err)
+		apierror.Write(w, http.StatusInternalServerError, apierror.CodeInternal, "Failed to read message status")
+		return nil, false
+	}
+	service, ok := auth.ServiceFromContext(ctx)
+	if msg == nil || !ok || (msg.Service != service.ID && !service.Admin) {
+		apierror.Write(w, http.StatusNotFound, apierror.CodeNotFound, "Message not found")
+		return nil, false
+	}
+	return msg, true
+}
+
+// recordMessage starts tracking a sent message, on behalf of the calling
+// service unless msg.Service is set. Failures are logged rather than failing
+// the request, since the message has already been sent.
+func (h *Handler) recordMessage(ctx context.Context, msg *tracking.Message) {
+	if msg.CreatedAt.IsZero() {
+		msg.CreatedAt = time.Now().UTC()
+	}
+	if service, ok := auth.ServiceFromContext(ctx); ok && msg.Service == "" {
+		msg.Service = service.ID
+	}
+	if err := h.messages.Record(ctx, msg); err != nil {
+		h.logger.WarnContext(ctx, "Failed to record message status", "message_id", msg.ID, "error", err)
+	}
+}
+
+// recipientStatuses converts tracked recipients to their API form.
+func recipientStatuses(recipients []tracking.Recipient) []api.RecipientStatus {
+	statuses := make([]api.RecipientStatus, len(recipients))
+	for i, r := range recipients {
+		statuses[i] = api.RecipientStatus{
+			Address:   r.Address,
+			Status:    api.RecipientStatusStatus(r.Status),
+			UpdatedAt: r.UpdatedAt,
+		}
+		if r.Detail != "" {
+			statuses[i].Detail = &r.Detail
+		}
+	}
+	return statuses
+}
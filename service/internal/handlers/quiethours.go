@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/auth"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/quiethours"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/schedule"
+	"github.com/Low-Stack-Technologies/message-delivery-service/pkg/api"
+)
+
+// recipientLocations returns the time zones an SMS recipient may be in: its
+// timeZone, else the zones of its country, else nil.
+func recipientLocations(r api.SmsRecipient1) ([]*time.Location, error) {
+	if r.TimeZone != nil {
+		loc, err := time.LoadLocation(*r.TimeZone)
+		if err != nil || *r.TimeZone == "" {
+			return nil, fmt.Errorf("unknown time zone %q", *r.TimeZone)
+		}
+		return []*time.Location{loc}, nil
+	}
+	locs, _ := quiethours.CountryLocations(r.Country)
+	return locs, nil
+}
+
+// quietHours returns when to send to each recipient, given the time zones
+// each may be in (nil when unknown): at sendAt, or the zero time for now,
+// unless that falls in the calling service's quiet hours in any of them, in
+// which case once the quiet hours have ended in all of them. When no time of
+// day is outside the quiet hours in every zone of a recipient's country, the
+// zone of its capital is used. OTP messages are never deferred.
+func (h *Handler) quietHours(ctx context.Context, class *api.SmsRequestClass, sendAt time.Time, zones [][]*time.Location) []time.Time {
+	times := make([]time.Time, len(zones))
+	for i := range times {
+		times[i] = sendAt
+	}
+	service, ok := auth.ServiceFromContext(ctx)
+	if !ok || !service.QuietHours.Enabled() || (class != nil && *class == api.Otp) {
+		return times
+	}
+
+	cfg := service.QuietHours
+	fallback, err := cfg.Location()
+	if err != nil {
+		fallback = time.UTC
+	}
+	at := sendAt
+	if at.IsZero() {
+		at = time.Now()
+	}
+	for i, locs := range zones {
+		if len(locs) == 0 {
+			locs = []*time.Location{fallback}
+		}
+		next, ok := quiethours.NextAll(cfg, at, locs)
+		if !ok {
+			next = quiethours.Next(cfg, at, locs[0])
+		}
+		if !next.Equal(at) {
+			times[i] = next.UTC()
+		}
+	}
+	return times
+}
+
+// smsJobs groups the numbers of an SMS into one job per send time, in the
+// order of the numbers. Numbers to send now are due immediately; the others
+// are reported as deferred when they were moved from sendAt.
+func smsJobs(numbers []string, times []time.Time, sendAt time.Time, sms schedule.SMS, scope string) []*schedule.Job {
+	now := time.Now().UTC()
+	var jobs []*schedule.Job
+	byTime := make(map[time.Time]*schedule.Job)
+	for i, number := range numbers {
+		at := times[i]
+		if at.IsZero() {
+			at = now
+		}
+		job, ok := byTime[at]
+		if !ok {
+			content := sms
+			content.To = nil
+			job = &schedule.Job{Channel: "sms", Scope: scope, SendAt: at, SMS: &content}
+			if !times[i].Equal(sendAt) {
+				job.Detail = "deferred by quiet hours until " + at.Format(time.RFC3339)
+			}
+			byTime[at] = job
+			jobs = append(jobs, job)
+		}
+		job.SMS.To = append(job.SMS.To, number)
+	}
+	return jobs
+}
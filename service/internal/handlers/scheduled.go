@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/apierror"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/auth"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/schedule"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/tracing"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/tracking"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// requestSendAt returns when a request is to be sent, or the zero time to send
+// it now, writing a 400 response when it is too far ahead.
+func requestSendAt(w http.ResponseWriter, sendAt *time.Time) (time.Time, bool) {
+	now := time.Now()
+	if sendAt == nil || !sendAt.After(now) {
+		return time.Time{}, true
+	}
+	if sendAt.After(now.Add(schedule.MaxDelay)) {
+		apierror.Write(w, http.StatusBadRequest, apierror.CodeInvalidBody, "Invalid request body",
+			"sendAt: must be at most a year ahead")
+		return time.Time{}, false
+	}
+	return sendAt.UTC(), true
+}
+
+// schedule queues the jobs of a message on behalf of the calling service and
+// tracks it with the status SCHEDULED for each recipient, which it returns.
+// The jobs share the ID of the first one, which is generated if empty.
+func (h *Handler) schedule(ctx context.Context, from string, jobs ...*schedule.Job) ([]tracking.Recipient, error) {
+	id := jobs[0].ID
+	if id == "" {
+		var err error
+		if id, err = schedule.NewID(); err != nil {
+			return nil, err
+		}
+	}
+	var service string
+	if s, ok := auth.ServiceFromContext(ctx); ok {
+		service = s.ID
+	}
+	now := time.Now().UTC()
+
+	// 1. Track first, so that the message can be looked up and cancelled as
+	// soon as it is queued.
+	msg := &tracking.Message{ID: id, Channel: jobs[0].Channel, Service: service, From: from, CreatedAt: now}
+	for _, job := range jobs {
+		job.ID, job.Service, job.CreatedAt = id, service, now
+		msg.Recipients = append(msg.Recipients, jobStatuses(job, tracking.StatusScheduled, job.Detail)...)
+	}
+	if err := h.messages.Record(ctx, msg); err != nil {
+		return nil, err
+	}
+
+	// 2. Queue
+	for _, job := range jobs {
+		if err := h.scheduled.Add(ctx, job); err != nil {
+			h.scheduled.Cancel(ctx, id)
+			if err := h.messages.UpdateAll(ctx, id, tracking.StatusFailed, "not scheduled"); err != nil {
+				h.logger.WarnContext(ctx, "Failed to record message status", "message_id", id, "error", err)
+			}
+			return nil, err
+		}
+		h.logger.InfoContext(ctx, "Message scheduled", "message_id", id, "channel", job.Channel, "send_at", job.SendAt)
+	}
+	return msg.Recipients, nil
+}
+
+// jobStatuses returns the given status for each recipient of job.
+func jobStatuses(job *schedule.Job, status tracking.Status, detail string) []tracking.Recipient {
+	recipients := job.Recipients()
+	statuses := make([]tracking.Recipient, len(recipients))
+	for i, recipient := range recipients {
+		statuses[i] = tracking.Recipient{Address: recipient, Status: status, Detail: detail, UpdatedAt: time.Now().UTC()}
+	}
+	return statuses
+}
+
+// updateStatuses sets the tracked status of the given recipients of a
+// message, leaving the others, which may be sent by other jobs, as they are.
+func (h *Handler) updateStatuses(ctx context.Context, id string, statuses []tracking.Recipient) {
+	for _, s := range statuses {
+		if err := h.messages.Update(ctx, id, s.Address, s.Status, s.Detail); err != nil {
+			h.logger.WarnContext(ctx, "Failed to record message status", "message_id", id, "recipient", s.Address, "error", err)
+		}
+	}
+}
+
+// Scheduled returns the dispatcher that sends scheduled messages once they
+// are due.
+func (h *Handler) Scheduled() schedule.Dispatcher {
+	return scheduledSender{h}
+}
+
+// scheduledSender sends due messages like a send request would, checking
+// suppressions at the time of sending.
+type scheduledSender struct {
+	h *Handler
+}
+
+func (d scheduledSender) Dispatch(ctx context.Context, job *schedule.Job) (err error) {
+	h := d.h
+	ctx, span := tracing.Start(ctx, "dispatch "+job.Channel, attribute.String("mds.message_id", job.ID))
+	defer func() { tracing.End(span, err) }()
+
+	var statuses []tracking.Recipient
+	switch {
+	case job.Email != nil:
+		// Filter a copy: the job is retried as queued if sending fails.
+		email := *job.Email
+		if statuses, err = h.filterEmail(ctx, &email, job.Scope); err != nil {
+			return err
+		}
+		if len(email.To) > 0 {
+			if err := queue(ctx, "email", len(email.To), func(ctx context.Context) error {
+				_, err := h.email.Send(ctx, &email)
+				return err
+			}); err != nil {
+				return err
+			}
+		}
+	case job.SMS != nil:
+		var kept []string
+		if statuses, kept, err = h.filterSms(ctx, job.SMS.To, job.Scope); err != nil {
+			return err
+		}
+		// Send one number at a time, so that a retry only sends to the
+		// numbers that have not been sent to yet.
+		for i, number := range kept {
+			if err := queue(ctx, "sms", 1, func(ctx context.Context) error {
+				return h.sms.Send(ctx, job.SMS.Sender, []string{number}, job.SMS.Body)
+			}); err != nil {
+				job.SMS.To = kept[i:]
+				h.updateStatuses(ctx, job.ID, slices.DeleteFunc(statuses, func(s tracking.Recipient) bool {
+					return slices.Contains(job.SMS.To, s.Address)
+				}))
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("scheduled message %s has no content", job.ID)
+	}
+	h.logger.InfoContext(ctx, "Scheduled message sent", "message_id", job.ID, "channel", job.Channel)
+	h.updateStatuses(ctx, job.ID, statuses)
+	return nil
+}
+
+func (d scheduledSender) Fail(ctx context.Context, job *schedule.Job, err error) {
+	d.h.updateStatuses(ctx, job.ID, jobStatuses(job, tracking.StatusFailed, err.Error()))
+}
+
+func (d scheduledSender) Cancel(ctx context.Context, job *schedule.Job) {
+	d.h.updateStatuses(ctx, job.ID, jobStatuses(job, tracking.StatusCancelled, ""))
+}
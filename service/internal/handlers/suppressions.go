@@ -0,0 +1,256 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"time"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/apierror"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/auth"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/config"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/suppression"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/tracking"
+	"github.com/Low-Stack-Technologies/message-delivery-service/pkg/api"
+)
+
+func (h *Handler) GetV3Suppressions(w http.ResponseWriter, r *http.Request, params api.GetV3SuppressionsParams) {
+	ctx := r.Context()
+	scope, ok := requestScope(w, params.Scope)
+	if !ok {
+		return
+	}
+	entries, err := h.suppressions.List(ctx, scope)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to list suppressions", "error", err)
+		apierror.Write(w, http.StatusInternalServerError, apierror.CodeInternal, "Failed to list suppressions")
+		return
+	}
+
+	// Services only see global entries and their own; admins see them all.
+	service, _ := auth.ServiceFromContext(ctx)
+	resp := api.SuppressionList{Entries: []api.SuppressionEntry{}}
+	for _, e := range entries {
+		if e.Scope == suppression.ScopeGlobal || ownSuppression(service, &e) {
+			resp.Entries = append(resp.Entries, suppressionEntry(e))
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (h *Handler) PostV3Suppressions(w http.ResponseWriter, r *http.Request, params api.PostV3SuppressionsParams) {
+	ctx := r.Context()
+	var req api.SuppressionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Write(w, http.StatusBadRequest, apierror.CodeInvalidBody, "Invalid request body", apierror.DecodeDetails(err)...)
+		return
+	}
+
+	// 1. Validate
+	entry := suppression.Entry{Recipient: suppression.Normalize(req.Recipient), Reason: suppression.ReasonManual}
+	if entry.Recipient == "" {
+		apierror.Write(w, http.StatusBadRequest, apierror.CodeInvalidBody, "Invalid request body", "recipient: is required")
+		return
+	}
+	scope, ok := requestScope(w, req.Scope)
+	if !ok {
+		return
+	}
+	entry.Scope = suppression.ScopeGlobal
+	if scope != "" {
+		entry.Scope = scope
+	}
+	if req.Reason != nil {
+		switch reason := string(*req.Reason); reason {
+		case suppression.ReasonManual, suppression.ReasonUnsubscribe, suppression.ReasonHardBounce, suppression.ReasonComplaint:
+			entry.Reason = reason
+		default:
+			apierror.Write(w, http.StatusBadRequest, apierror.CodeInvalidBody, "Invalid request body",
+				fmt.Sprintf("reason: unknown value %q (expected MANUAL, UNSUBSCRIBE, HARD_BOUNCE or COMPLAINT)", reason))
+			return
+		}
+	}
+	if req.Detail != nil {
+		entry.Detail = *req.Detail
+	}
+	if service, ok := auth.ServiceFromContext(ctx); ok {
+		entry.Service = service.ID
+	}
+
+	// 2. Store
+	entry.CreatedAt = time.Now().UTC()
+	if err := h.suppressions.Add(ctx, entry); err != nil {
+		h.logger.ErrorContext(ctx, "Failed to add suppression", "error", err)
+		apierror.Write(w, http.StatusInternalServerError, apierror.CodeInternal, "Failed to add suppression")
+		return
+	}
+	h.logger.InfoContext(ctx, "Recipient suppressed", "recipient", entry.Recipient, "scope", entry.Scope, "reason", entry.Reason)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(suppressionEntry(entry))
+}
+
+func (h *Handler) DeleteV3SuppressionsRecipient(w http.ResponseWriter, r *http.Request, recipient string, params api.DeleteV3SuppressionsRecipientParams) {
+	ctx := r.Context()
+	scope, ok := requestScope(w, params.Scope)
+	if !ok {
+		return
+	}
+
+	// 1. Authorize
+	entry, found, err := h.suppressions.Get(ctx, recipient, scope)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to read suppression", "error", err)
+		apierror.Write(w, http.StatusInternalServerError, apierror.CodeInternal, "Failed to read suppression")
+		return
+	}
+	if !found {
+		apierror.Write(w, http.StatusNotFound, apierror.CodeNotFound, "Recipient is not suppressed")
+		return
+	}
+	// Global entries come from bounces and complaints or apply to every
+	// service, so only admins may lift them or other services' entries.
+	service, ok := auth.ServiceFromContext(ctx)
+	if !ok || !ownSuppression(service, entry) || (entry.Scope == suppression.ScopeGlobal && !service.Admin) {
+		apierror.Write(w, http.StatusForbidden, apierror.CodeForbidden, "Admin access required to lift this suppression")
+		return
+	}
+
+	// 2. Remove
+	err = h.suppressions.Remove(ctx, recipient, scope)
+	if errors.Is(err, suppression.ErrNotFound) {
+		apierror.Write(w, http.StatusNotFound, apierror.CodeNotFound, "Recipient is not suppressed")
+		return
+	}
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to remove suppression", "error", err)
+		apierror.Write(w, http.StatusInternalServerError, apierror.CodeInternal, "Failed to remove suppression")
+		return
+	}
+	h.logger.InfoContext(ctx, "Suppression lifted", "recipient", recipient, "scope", scope)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(api.SuccessResponse{
+		Success: true,
+		Message: "Suppression lifted",
+	})
+}
+
+// unsubscribePage confirms an unsubscribe link. GET only shows the form, so
+// that link scanners do not unsubscribe anyone; POST applies it.
+var unsubscribePage = template.Must(template.New("unsubscribe").Parse(`<!DOCTYPE html>
+<html><head><meta charset="utf-8"><title>Unsubscribe</title></head>
+<body>
+{{if .Done}}<p>{{.Recipient}} has been unsubscribed.</p>
+{{else}}<form method="post">
+<input type="hidden" name="List-Unsubscribe" value="One-Click">
+<p>Unsubscribe {{.Recipient}}?</p>
+<button type="submit">Unsubscribe</button>
+</form>
+{{end}}</body></html>
+`))
+
+func (h *Handler) GetUnsubscribeToken(w http.ResponseWriter, r *http.Request, token string) {
+	recipient, _, ok := h.unsubscribeToken(w, r, token)
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	unsubscribePage.Execute(w, map[string]any{"Recipient": recipient})
+}
+
+func (h *Handler) PostUnsubscribeToken(w http.ResponseWriter, r *http.Request, token string) {
+	ctx := r.Context()
+	recipient, scope, ok := h.unsubscribeToken(w, r, token)
+	if !ok {
+		return
+	}
+	entry := suppression.Entry{Recipient: recipient, Scope: scope, Reason: suppression.ReasonUnsubscribe, Detail: "one-click unsubscribe"}
+	if err := h.suppressions.Add(ctx, entry); err != nil {
+		h.logger.ErrorContext(ctx, "Failed to unsubscribe", "error", err)
+		apierror.Write(w, http.StatusInternalServerError, apierror.CodeInternal, "Failed to unsubscribe")
+		return
+	}
+	h.logger.InfoContext(ctx, "Recipient unsubscribed", "recipient", recipient, "scope", scope)
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	unsubscribePage.Execute(w, map[string]any{"Recipient": recipient, "Done": true})
+}
+
+// unsubscribeToken verifies the token of an unsubscribe link, writing the
+// error response when it is invalid.
+func (h *Handler) unsubscribeToken(w http.ResponseWriter, r *http.Request, token string) (recipient, scope string, ok bool) {
+	cfg := h.store.Get().Unsubscribe
+	if !cfg.Enabled() {
+		apierror.NotFound(w, r)
+		return "", "", false
+	}
+	recipient, scope, err := suppression.ParseUnsubscribeToken(cfg.Secret.Value(), token)
+	if err != nil {
+		h.logger.DebugContext(r.Context(), "Invalid unsubscribe link", "error", err)
+		apierror.Write(w, http.StatusBadRequest, apierror.CodeInvalidParameter, "Invalid unsubscribe link")
+		return "", "", false
+	}
+	return recipient, scope, true
+}
+
+// requestScope returns the suppression scope of a request, or "" when it has
+// none, writing a 400 response when it is invalid.
+func requestScope(w http.ResponseWriter, scope *string) (string, bool) {
+	if scope == nil {
+		return "", true
+	}
+	if !suppression.ValidScope(*scope) {
+		apierror.Write(w, http.StatusBadRequest, apierror.CodeInvalidBody, "Invalid request body",
+			"scope: must be 1 to 64 letters, digits, dots, dashes or underscores")
+		return "", false
+	}
+	return *scope, true
+}
+
+// filterSuppressed returns the status of each recipient: SUPPRESSED when it is
+// suppressed globally or in scope, and SENT otherwise.
+func (h *Handler) filterSuppressed(ctx context.Context, recipients []string, scope string) ([]tracking.Recipient, error) {
+	now := time.Now().UTC()
+	statuses := make([]tracking.Recipient, len(recipients))
+	for i, recipient := range recipients {
+		statuses[i] = tracking.Recipient{Address: recipient, Status: tracking.StatusSent, UpdatedAt: now}
+		e, suppressed, err := h.suppressions.Check(ctx, recipient, scope)
+		if err != nil {
+			return nil, err
+		}
+		if suppressed {
+			statuses[i].Status = tracking.StatusSuppressed
+			statuses[i].Detail = fmt.Sprintf("%s (%s)", e.Reason, e.Scope)
+			h.logger.DebugContext(ctx, "Skipping suppressed recipient", "recipient", recipient, "scope", e.Scope, "reason", e.Reason)
+		}
+	}
+	return statuses, nil
+}
+
+// ownSuppression reports whether e was added by service, or service is an
+// admin.
+func ownSuppression(service *config.Service, e *suppression.Entry) bool {
+	return service != nil && (service.Admin || e.Service == service.ID)
+}
+
+func suppressionEntry(e suppression.Entry) api.SuppressionEntry {
+	entry := api.SuppressionEntry{
+		Recipient: e.Recipient,
+		Scope:     e.Scope,
+		Reason:    api.SuppressionEntryReason(e.Reason),
+		CreatedAt: e.CreatedAt,
+	}
+	if e.Detail != "" {
+		entry.Detail = &e.Detail
+	}
+	if e.Service != "" {
+		entry.Service = &e.Service
+	}
+	return entry
+}
@@ -0,0 +1,148 @@
+// Package health probes the service's delivery dependencies for the
+// readiness endpoint. Probe results are cached so that frequent polling by
+// orchestrators does not turn into load on the providers.
+package health
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/config"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/logging"
+)
+
+// Dependency statuses.
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// Probe checks one dependency.
+type Probe struct {
+	Name  string // e.g. "smtp:support@example.com"
+	Kind  string // e.g. "smtp"
+	Check func(ctx context.Context) error
+}
+
+// Source lists the probes for the dependencies it manages. The built-in
+// providers implement it; injected providers that implement it are probed
+// as well.
+type Source interface {
+	Probes() []Probe
+}
+
+// Result is the outcome of one probe. Name and Error are masked like log
+// messages since the readiness endpoint is public.
+type Result struct {
+	Name      string
+	Kind      string
+	Status    string
+	Error     string
+	Latency   time.Duration
+	CheckedAt time.Time
+}
+
+// Report is the outcome of probing every dependency.
+type Report struct {
+	Ready     bool
+	CheckedAt time.Time
+	Checks    []Result
+}
+
+// Checker probes the dependencies of its sources and caches the report for
+// the configured health.interval.
+type Checker struct {
+	store   *config.Store
+	logger  *slog.Logger
+	sources []Source
+
+	mu     sync.Mutex
+	report *Report
+	status map[string]string // last status by probe name, for logging changes
+}
+
+// NewChecker probes the dependencies listed by sources. A config reload
+// discards the cached report, since accounts may have changed.
+func NewChecker(store *config.Store, logger *slog.Logger, sources ...Source) *Checker {
+	c := &Checker{
+		store:   store,
+		logger:  logger,
+		sources: sources,
+		status:  make(map[string]string),
+	}
+	store.Subscribe(func(*config.Config) {
+		c.mu.Lock()
+		c.report = nil
+		c.mu.Unlock()
+	})
+	return c
+}
+
+// Report returns the cached report, probing again when it has expired.
+// Concurrent callers share a single round of probes.
+func (c *Checker) Report(ctx context.Context) Report {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cfg := c.store.Get().Health
+	if c.report != nil && time.Since(c.report.CheckedAt) < cfg.Interval {
+		return *c.report
+	}
+
+	// The report outlives the request that triggered it, so a client
+	// hanging up must not fail the probes.
+	report := c.probe(context.WithoutCancel(ctx), cfg.Timeout)
+	c.report = &report
+	return report
+}
+
+func (c *Checker) probe(ctx context.Context, timeout time.Duration) Report {
+	var probes []Probe
+	for _, src := range c.sources {
+		probes = append(probes, src.Probes()...)
+	}
+
+	report := Report{Ready: true, CheckedAt: time.Now(), Checks: make([]Result, len(probes))}
+	var wg sync.WaitGroup
+	for i, p := range probes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Checks[i] = run(ctx, p, timeout)
+		}()
+	}
+	wg.Wait()
+
+	for _, res := range report.Checks {
+		if res.Status != StatusUp {
+			report.Ready = false
+		}
+		if prev := c.status[res.Name]; prev != res.Status {
+			if res.Status == StatusUp {
+				if prev != "" {
+					c.logger.InfoContext(ctx, "Dependency recovered", "dependency", res.Name)
+				}
+			} else {
+				c.logger.WarnContext(ctx, "Dependency down", "dependency", res.Name, "error", res.Error)
+			}
+		}
+		c.status[res.Name] = res.Status
+	}
+	return report
+}
+
+func run(ctx context.Context, p Probe, timeout time.Duration) Result {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	res := Result{Name: logging.MaskText(p.Name), Kind: p.Kind, Status: StatusUp, CheckedAt: time.Now()}
+	err := p.Check(ctx)
+	res.Latency = time.Since(res.CheckedAt)
+	if err != nil {
+		res.Status = StatusDown
+		res.Error = logging.MaskText(err.Error())
+	}
+	return res
+}
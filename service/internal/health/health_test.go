@@ -0,0 +1,85 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/config"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/logging"
+)
+
+type fakeSource struct {
+	calls atomic.Int32
+	err   atomic.Pointer[error]
+}
+
+func (f *fakeSource) Probes() []Probe {
+	return []Probe{{
+		Name: "smtp:support@example.com",
+		Kind: "smtp",
+		Check: func(ctx context.Context) error {
+			f.calls.Add(1)
+			if err := f.err.Load(); err != nil {
+				return *err
+			}
+			return nil
+		},
+	}}
+}
+
+func newStore(interval time.Duration) *config.Store {
+	return config.NewStaticStore(&config.Config{
+		Health: config.HealthConfig{Interval: interval, Timeout: time.Second},
+	})
+}
+
+func TestChecker_CachesReport(t *testing.T) {
+	src := &fakeSource{}
+	store := newStore(time.Hour)
+	checker := NewChecker(store, logging.Discard(), src)
+
+	for i := 0; i < 3; i++ {
+		if report := checker.Report(context.Background()); !report.Ready {
+			t.Fatalf("Expected ready, got %+v", report)
+		}
+	}
+	if got := src.calls.Load(); got != 1 {
+		t.Errorf("Expected one probe within the interval, got %d", got)
+	}
+
+	// A reload discards the cached report.
+	store.Set(&config.Config{Health: config.HealthConfig{Interval: time.Hour, Timeout: time.Second}})
+	checker.Report(context.Background())
+	if got := src.calls.Load(); got != 2 {
+		t.Errorf("Expected a new probe after reload, got %d probes", got)
+	}
+}
+
+func TestChecker_ReportsFailures(t *testing.T) {
+	src := &fakeSource{}
+	err := errors.New("535 authentication failed for support@example.com")
+	src.err.Store(&err)
+	checker := NewChecker(newStore(0), logging.Discard(), src)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel() // The probes must not depend on the triggering request.
+	report := checker.Report(ctx)
+	if report.Ready || len(report.Checks) != 1 {
+		t.Fatalf("Expected one failed check, got %+v", report)
+	}
+	res := report.Checks[0]
+	if res.Status != StatusDown || res.Kind != "smtp" {
+		t.Errorf("Unexpected result: %+v", res)
+	}
+	if res.Name != "smtp:s***@example.com" || res.Error != "535 authentication failed for s***@example.com" {
+		t.Errorf("Expected masked name and error, got %q and %q", res.Name, res.Error)
+	}
+
+	src.err.Store(nil)
+	if report := checker.Report(context.Background()); !report.Ready {
+		t.Errorf("Expected recovery to be picked up once the report expired, got %+v", report)
+	}
+}
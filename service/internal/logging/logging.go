@@ -0,0 +1,115 @@
+// Package logging builds the service's structured loggers. Every logger it
+// returns masks personal data and secrets and tags records with the request
+// ID and trace of the context they were logged with.
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/config"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RequestIDKey is the attribute carrying chi's request ID.
+const RequestIDKey = "request_id"
+
+// Attributes linking a record to the trace it was logged in.
+const (
+	TraceIDKey = "trace_id"
+	SpanIDKey  = "span_id"
+)
+
+// New returns a logger writing to w in the configured format, and the level
+// variable controlling it so that reloads can change the level in place.
+func New(w io.Writer, cfg *config.Config) (*slog.Logger, *slog.LevelVar) {
+	level := new(slog.LevelVar)
+	level.Set(Level(cfg))
+
+	opts := &slog.HandlerOptions{Level: level}
+	var h slog.Handler
+	if strings.EqualFold(cfg.Logging.Format, "json") {
+		h = slog.NewJSONHandler(w, opts)
+	} else {
+		h = slog.NewTextHandler(w, opts)
+	}
+	return slog.New(NewHandler(h)), level
+}
+
+// Wrap adds masking, request IDs and trace IDs to a logger supplied by an
+// embedder.
+func Wrap(l *slog.Logger) *slog.Logger {
+	if _, ok := l.Handler().(*handler); ok {
+		return l
+	}
+	return slog.New(NewHandler(l.Handler()))
+}
+
+// Level returns the configured level. The legacy `debug: true` switch
+// selects debug when logging.level is not set.
+func Level(cfg *config.Config) slog.Level {
+	switch strings.ToLower(cfg.Logging.Level) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	case "info":
+		return slog.LevelInfo
+	}
+	if cfg.Debug {
+		return slog.LevelDebug
+	}
+	return slog.LevelInfo
+}
+
+// Discard returns a logger that drops everything, for tests.
+func Discard() *slog.Logger {
+	return slog.New(slog.DiscardHandler)
+}
+
+// handler masks the message and attributes of every record before passing it
+// on, and adds the request ID and trace from the context.
+type handler struct {
+	inner slog.Handler
+}
+
+// NewHandler wraps h with masking and request ID and trace tagging.
+func NewHandler(h slog.Handler) slog.Handler {
+	return &handler{inner: h}
+}
+
+func (h *handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.inner.Enabled(ctx, level)
+}
+
+func (h *handler) Handle(ctx context.Context, r slog.Record) error {
+	masked := slog.NewRecord(r.Time, r.Level, MaskText(r.Message), r.PC)
+	if id := middleware.GetReqID(ctx); id != "" {
+		masked.AddAttrs(slog.String(RequestIDKey, id))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsSampled() {
+		masked.AddAttrs(slog.String(TraceIDKey, sc.TraceID().String()), slog.String(SpanIDKey, sc.SpanID().String()))
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		masked.AddAttrs(redactAttr(a))
+		return true
+	})
+	return h.inner.Handle(ctx, masked)
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	masked := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		masked[i] = redactAttr(a)
+	}
+	return &handler{inner: h.inner.WithAttrs(masked)}
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	return &handler{inner: h.inner.WithGroup(name)}
+}
@@ -0,0 +1,122 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/config"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestMaskText(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"send to jane.doe@example.com", "send to j***@example.com"},
+		{"sms +46701234567 ok", "sms +46*******67 ok"},
+		{"domestic 0701234567", "domestic 07******67"},
+		{"Signature c2lnbmF0dXJl+/==", "Signature [REDACTED]"},
+		{"port 587, 1048576 bytes, sha256 9f86d081884c7d65", "port 587, 1048576 bytes, sha256 9f86d081884c7d65"},
+	}
+	for _, tt := range tests {
+		if got := MaskText(tt.in); got != tt.want {
+			t.Errorf("MaskText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestHandler_MasksRecords(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewHandler(slog.NewJSONHandler(&buf, nil))).
+		With("account", "support@example.com")
+
+	ctx := context.WithValue(context.Background(), middleware.RequestIDKey, "req-1")
+	logger.InfoContext(ctx, "Delivering to bob@example.org",
+		"authorization", "Signature abc",
+		"to", []string{"alice@example.com", "+46701234567"},
+		"error", errors.New("550 mailbox carol@example.net unavailable"),
+		slog.Group("sms", "phone", "+46709876543"),
+	)
+
+	out := buf.String()
+	for _, leak := range []string{"support@", "bob@", "alice@", "carol@", "abc", "46701234567", "46709876543"} {
+		if strings.Contains(out, leak) {
+			t.Errorf("Log output leaks %q: %s", leak, out)
+		}
+	}
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("Output is not JSON: %v", err)
+	}
+	if record[RequestIDKey] != "req-1" {
+		t.Errorf("Expected request ID from context, got %v", record[RequestIDKey])
+	}
+	if record["msg"] != "Delivering to b***@example.org" {
+		t.Errorf("Unexpected message: %v", record["msg"])
+	}
+}
+
+func TestHandler_TagsTrace(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewHandler(slog.NewJSONHandler(&buf, nil)))
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+	logger.InfoContext(ctx, "Email delivered")
+
+	if !strings.Contains(buf.String(), `"trace_id":"4bf92f3577b34da6a3ce929d0e0e4736","span_id":"00f067aa0ba902b7"`) {
+		t.Errorf("Expected trace and span IDs, got %s", buf.String())
+	}
+}
+
+func TestLevel(t *testing.T) {
+	tests := []struct {
+		cfg  *config.Config
+		want slog.Level
+	}{
+		{&config.Config{}, slog.LevelInfo},
+		{&config.Config{Debug: true}, slog.LevelDebug},
+		{&config.Config{Debug: true, Logging: config.LoggingConfig{Level: "warn"}}, slog.LevelWarn},
+		{&config.Config{Logging: config.LoggingConfig{Level: "ERROR"}}, slog.LevelError},
+	}
+	for _, tt := range tests {
+		if got := Level(tt.cfg); got != tt.want {
+			t.Errorf("Level(%+v) = %v, want %v", tt.cfg.Logging, got, tt.want)
+		}
+	}
+}
+
+func TestMiddleware_LogsRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewHandler(slog.NewJSONHandler(&buf, nil)))
+
+	handler := middleware.RequestID(Middleware(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	})))
+
+	req := httptest.NewRequest(http.MethodPost, "/v3/email", nil)
+	req.Header.Set(middleware.RequestIDHeader, "from-proxy")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Header().Get(RequestIDHeader) != "from-proxy" {
+		t.Errorf("Expected request ID to be echoed, got %q", rec.Header().Get(RequestIDHeader))
+	}
+	if !strings.Contains(buf.String(), `"request_id":"from-proxy"`) || !strings.Contains(buf.String(), `"status":202`) {
+		t.Errorf("Unexpected access log: %s", buf.String())
+	}
+}
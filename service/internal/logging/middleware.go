@@ -0,0 +1,41 @@
+package logging
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// RequestIDHeader echoes the request ID so clients can quote it in reports.
+const RequestIDHeader = "X-Request-Id"
+
+// Middleware logs one line per request. It must run after chi's RequestID
+// middleware so the line carries the request ID.
+func Middleware(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if id := middleware.GetReqID(r.Context()); id != "" {
+				w.Header().Set(RequestIDHeader, id)
+			}
+
+			start := time.Now()
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r)
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			logger.InfoContext(r.Context(), "Request handled",
+				"method", r.Method,
+				"path", r.URL.Path,
+				"status", status,
+				"bytes", ww.BytesWritten(),
+				"duration_ms", float64(time.Since(start).Microseconds())/1000,
+				"remote", r.RemoteAddr,
+			)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package logging
+
+import (
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces values that must never be logged.
+const Redacted = "[REDACTED]"
+
+// secretKeys are attribute keys whose values are always replaced.
+var secretKeys = map[string]bool{
+	"authorization": true,
+	"signature":     true,
+	"password":      true,
+	"token":         true,
+	"secret":        true,
+}
+
+var (
+	emailPattern     = regexp.MustCompile(`([A-Za-z0-9._%+\-])[A-Za-z0-9._%+\-]*@([A-Za-z0-9.\-]+\.[A-Za-z]{2,})`)
+	phonePattern     = regexp.MustCompile(`\+\d{7,15}\b|\b\d{9,15}\b`)
+	signaturePattern = regexp.MustCompile(`(Signature\s+)[A-Za-z0-9+/=_\-]+`)
+)
+
+// MaskText masks email addresses, phone numbers and request signatures in
+// free text, keeping enough of each to correlate log lines:
+// "jane@example.com" becomes "j***@example.com" and "+46701234567"
+// becomes "+46*******67".
+func MaskText(s string) string {
+	s = signaturePattern.ReplaceAllString(s, "${1}"+Redacted)
+	s = emailPattern.ReplaceAllString(s, "${1}***@${2}")
+	return phonePattern.ReplaceAllStringFunc(s, maskPhone)
+}
+
+func maskPhone(p string) string {
+	keep := 2
+	if strings.HasPrefix(p, "+") {
+		keep = 3
+	}
+	return p[:keep] + strings.Repeat("*", len(p)-keep-2) + p[len(p)-2:]
+}
+
+func redactAttr(a slog.Attr) slog.Attr {
+	a.Value = a.Value.Resolve()
+	if secretKeys[strings.ToLower(a.Key)] {
+		return slog.String(a.Key, Redacted)
+	}
+
+	switch a.Value.Kind() {
+	case slog.KindString:
+		a.Value = slog.StringValue(MaskText(a.Value.String()))
+	case slog.KindGroup:
+		attrs := a.Value.Group()
+		masked := make([]slog.Attr, len(attrs))
+		for i, attr := range attrs {
+			masked[i] = redactAttr(attr)
+		}
+		a.Value = slog.GroupValue(masked...)
+	case slog.KindAny:
+		switch v := a.Value.Any().(type) {
+		case error:
+			a.Value = slog.StringValue(MaskText(v.Error()))
+		case []string:
+			masked := make([]string, len(v))
+			for i, s := range v {
+				masked[i] = MaskText(s)
+			}
+			a.Value = slog.AnyValue(masked)
+		case fmt.Stringer:
+			a.Value = slog.StringValue(MaskText(v.String()))
+		}
+	}
+	return a
+}
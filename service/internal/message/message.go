@@ -0,0 +1,201 @@
+// Package message builds RFC 5322 email messages with MIME bodies.
+package message
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Line lengths recommended by RFC 5322 and RFC 2045. Servers must accept
+// lines of up to 998 characters; longer lines are mangled or rejected.
+const (
+	foldLineLength  = 78
+	base64LineChars = 76
+)
+
+// ErrHeaderInjection is returned for header values that contain line breaks
+// or NUL, which would let them end the header and start another.
+var ErrHeaderInjection = errors.New("must not contain line breaks")
+
+// Email is a single-part text or HTML message.
+type Email struct {
+	From    mail.Address
+	To      []mail.Address
+	Subject string
+	Body    string
+	HTML    bool
+
+	// Date defaults to the time the message is built.
+	Date time.Time
+
+	// MessageID, without angle brackets, is generated when empty.
+	MessageID string
+
+	// ListUnsubscribe is a one-click unsubscribe URL (RFC 8058). When set,
+	// the List-Unsubscribe and List-Unsubscribe-Post headers are added.
+	ListUnsubscribe string
+}
+
+// Recipients returns the addresses of To, for the SMTP envelope.
+func (e *Email) Recipients() []string {
+	addrs := make([]string, len(e.To))
+	for i, to := range e.To {
+		addrs[i] = to.Address
+	}
+	return addrs
+}
+
+// Validate rejects header values that could inject headers. Problems are
+// reported with the request field they come from.
+func (e *Email) Validate() error {
+	var errs []error
+	check := func(field, value string) {
+		if strings.ContainsAny(value, "\r\n\x00") {
+			errs = append(errs, fmt.Errorf("%s: %w", field, ErrHeaderInjection))
+		}
+	}
+	check("from.name", e.From.Name)
+	check("from.address", e.From.Address)
+	for i, to := range e.To {
+		check(fmt.Sprintf("to[%d].name", i), to.Name)
+		check(fmt.Sprintf("to[%d].address", i), to.Address)
+	}
+	check("subject", e.Subject)
+	check("messageId", e.MessageID)
+	check("listUnsubscribe", e.ListUnsubscribe)
+	return errors.Join(errs...)
+}
+
+// Build encodes e and returns the message with CRLF line endings, and its
+// Message-ID without angle brackets. No line exceeds 998 characters: the
+// body is sent as quoted-printable or base64 unless it is short-lined ASCII.
+func Build(e *Email) (msg []byte, messageID string, err error) {
+	if err := e.Validate(); err != nil {
+		return nil, "", err
+	}
+	date := e.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+	messageID = e.MessageID
+	if messageID == "" {
+		if messageID, err = NewMessageID(e.From.Address); err != nil {
+			return nil, "", err
+		}
+	}
+
+	// 1. Headers
+	var b bytes.Buffer
+	to := make([]string, len(e.To))
+	for i, addr := range e.To {
+		to[i] = addr.String()
+	}
+	contentType := "text/plain"
+	if e.HTML {
+		contentType = "text/html"
+	}
+	body := normalizeNewlines(e.Body)
+	encoding := transferEncoding(body)
+
+	writeHeader(&b, "From", e.From.String())
+	writeHeader(&b, "To", strings.Join(to, ", "))
+	writeHeader(&b, "Subject", mime.QEncoding.Encode("utf-8", e.Subject))
+	writeHeader(&b, "Date", date.Format(time.RFC1123Z))
+	writeHeader(&b, "Message-ID", "<"+messageID+">")
+	if e.ListUnsubscribe != "" {
+		writeHeader(&b, "List-Unsubscribe", "<"+e.ListUnsubscribe+">")
+		writeHeader(&b, "List-Unsubscribe-Post", "List-Unsubscribe=One-Click")
+	}
+	writeHeader(&b, "MIME-Version", "1.0")
+	writeHeader(&b, "Content-Type", mime.FormatMediaType(contentType, map[string]string{"charset": "utf-8"}))
+	writeHeader(&b, "Content-Transfer-Encoding", encoding)
+	b.WriteString("\r\n")
+
+	// 2. Body
+	switch encoding {
+	case "quoted-printable":
+		w := quotedprintable.NewWriter(&b)
+		w.Write([]byte(body))
+		w.Close()
+	case "base64":
+		encoded := base64.StdEncoding.EncodeToString([]byte(body))
+		for len(encoded) > base64LineChars {
+			b.WriteString(encoded[:base64LineChars] + "\r\n")
+			encoded = encoded[base64LineChars:]
+		}
+		b.WriteString(encoded)
+	default:
+		b.WriteString(body)
+	}
+	return b.Bytes(), messageID, nil
+}
+
+// NewMessageID returns a unique Message-ID, without angle brackets, in the
+// domain of the sender's address.
+func NewMessageID(from string) (string, error) {
+	var random [12]byte
+	if _, err := rand.Read(random[:]); err != nil {
+		return "", err
+	}
+	domain := "localhost"
+	if at := strings.LastIndex(from, "@"); at >= 0 && at < len(from)-1 {
+		domain = from[at+1:]
+	}
+	return strconv.FormatInt(time.Now().UnixNano(), 36) + "." + hex.EncodeToString(random[:]) + "@" + domain, nil
+}
+
+// writeHeader writes a header field, folding it at spaces to keep lines
+// within 78 characters where possible.
+func writeHeader(b *bytes.Buffer, name, value string) {
+	line := name + ":"
+	for i, word := range strings.Split(value, " ") {
+		if i > 0 && len(line)+1+len(word) > foldLineLength {
+			b.WriteString(line + "\r\n")
+			line = ""
+		}
+		line += " " + word
+	}
+	b.WriteString(line + "\r\n")
+}
+
+// normalizeNewlines converts the line endings of s to CRLF.
+func normalizeNewlines(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\r", "\n")
+	return strings.ReplaceAll(s, "\n", "\r\n")
+}
+
+// transferEncoding picks 7bit for ASCII bodies with short lines, base64 for
+// bodies that are mostly non-ASCII (where quoted-printable would triple
+// their size) and quoted-printable otherwise.
+func transferEncoding(body string) string {
+	nonASCII, longLine := 0, false
+	for _, line := range strings.Split(body, "\r\n") {
+		if len(line) > foldLineLength {
+			longLine = true
+		}
+		for i := 0; i < len(line); i++ {
+			if c := line[i]; c >= 0x80 || (c < ' ' && c != '\t') {
+				nonASCII++
+			}
+		}
+	}
+	switch {
+	case nonASCII == 0 && !longLine:
+		return "7bit"
+	case nonASCII*3 > len(body):
+		return "base64"
+	default:
+		return "quoted-printable"
+	}
+}
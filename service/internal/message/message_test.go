@@ -0,0 +1,151 @@
+package message
+
+import (
+	"encoding/base64"
+	"errors"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuild(t *testing.T) {
+	longLine := strings.Repeat("<p>HTML without line breaks</p>", 50)
+	tests := []struct {
+		name     string
+		body     string
+		encoding string
+	}{
+		{"ascii", "Hello\nWorld", "7bit"},
+		{"long line", longLine, "quoted-printable"},
+		{"latin", "Hej då, välkommen!", "quoted-printable"},
+		{"mostly non-ascii", "Привет, добро пожаловать", "base64"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &Email{
+				From:    mail.Address{Name: "Support Åsa", Address: "support@example.com"},
+				To:      []mail.Address{{Address: "a@example.com"}, {Name: "B", Address: "b@example.com"}},
+				Subject: "Welcome",
+				Body:    tt.body,
+				HTML:    tt.name == "long line",
+			}
+			raw, id, err := Build(e)
+			if err != nil {
+				t.Fatalf("Build failed: %v", err)
+			}
+
+			for _, line := range strings.Split(string(raw), "\r\n") {
+				if len(line) > 78 {
+					t.Errorf("Line exceeds 78 characters: %q", line)
+				}
+			}
+			msg, err := mail.ReadMessage(strings.NewReader(string(raw)))
+			if err != nil {
+				t.Fatalf("Failed to parse message: %v", err)
+			}
+			if got := msg.Header.Get("Message-ID"); got != "<"+id+">" || !strings.HasSuffix(id, "@example.com") {
+				t.Errorf("Expected Message-ID <%s> in the sender's domain, got %q", id, got)
+			}
+			if _, err := msg.Header.Date(); err != nil {
+				t.Errorf("Invalid Date header: %v", err)
+			}
+			if got := msg.Header.Get("Content-Transfer-Encoding"); got != tt.encoding {
+				t.Errorf("Expected %s, got %s", tt.encoding, got)
+			}
+			from, err := msg.Header.AddressList("From")
+			if err != nil || from[0].Name != "Support Åsa" {
+				t.Errorf("Unexpected From: %v, %v", from, err)
+			}
+			if to, err := msg.Header.AddressList("To"); err != nil || len(to) != 2 {
+				t.Errorf("Unexpected To: %v, %v", to, err)
+			}
+
+			body, err := io.ReadAll(decodeBody(t, msg))
+			if err != nil {
+				t.Fatalf("Failed to decode body: %v", err)
+			}
+			if want := strings.ReplaceAll(tt.body, "\n", "\r\n"); string(body) != want {
+				t.Errorf("Expected body %q, got %q", want, body)
+			}
+		})
+	}
+}
+
+func TestBuild_FoldsEncodedSubject(t *testing.T) {
+	subject := strings.Repeat("Välkommen till vår tjänst ", 10)
+	raw, _, err := Build(&Email{From: mail.Address{Address: "a@example.com"}, Subject: subject, Date: time.Now()})
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	msg, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	if err != nil {
+		t.Fatalf("Failed to parse message: %v", err)
+	}
+	got, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil || got != subject {
+		t.Errorf("Expected subject %q, got %q (%v)", subject, got, err)
+	}
+}
+
+func TestBuild_ListUnsubscribe(t *testing.T) {
+	e := &Email{
+		From:            mail.Address{Address: "news@example.com"},
+		To:              []mail.Address{{Address: "a@example.com"}},
+		Subject:         "News",
+		ListUnsubscribe: "https://mds.example.com/unsubscribe/abc.def",
+	}
+	raw, _, err := Build(e)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	msg, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	if err != nil {
+		t.Fatalf("Failed to parse message: %v", err)
+	}
+	if got := msg.Header.Get("List-Unsubscribe"); got != "<https://mds.example.com/unsubscribe/abc.def>" {
+		t.Errorf("Unexpected List-Unsubscribe: %q", got)
+	}
+	if got := msg.Header.Get("List-Unsubscribe-Post"); got != "List-Unsubscribe=One-Click" {
+		t.Errorf("Unexpected List-Unsubscribe-Post: %q", got)
+	}
+
+	e.ListUnsubscribe = ""
+	if raw, _, _ = Build(e); strings.Contains(string(raw), "List-Unsubscribe") {
+		t.Error("Expected no List-Unsubscribe headers without a URL")
+	}
+}
+
+func TestValidate_RejectsHeaderInjection(t *testing.T) {
+	e := &Email{
+		From:    mail.Address{Name: "Evil\r\nBcc: victim@example.com", Address: "a@example.com"},
+		To:      []mail.Address{{Address: "b@example.com\nBcc: c@example.com"}},
+		Subject: "Hi\nX-Injected: 1",
+	}
+	err := e.Validate()
+	if !errors.Is(err, ErrHeaderInjection) {
+		t.Fatalf("Expected ErrHeaderInjection, got %v", err)
+	}
+	for _, field := range []string{"from.name", "to[0].address", "subject"} {
+		if !strings.Contains(err.Error(), field+": ") {
+			t.Errorf("Expected a problem with %s, got %v", field, err)
+		}
+	}
+	if _, _, err := Build(e); err == nil {
+		t.Error("Expected Build to refuse the message")
+	}
+}
+
+func decodeBody(t *testing.T, msg *mail.Message) io.Reader {
+	t.Helper()
+	switch msg.Header.Get("Content-Transfer-Encoding") {
+	case "quoted-printable":
+		return quotedprintable.NewReader(msg.Body)
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, msg.Body)
+	}
+	return msg.Body
+}
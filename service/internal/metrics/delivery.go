@@ -0,0 +1,84 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/delivery"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/message"
+)
+
+// Delivery channels.
+const (
+	ChannelEmail = "email"
+	ChannelSms   = "sms"
+)
+
+// QueueScheduled is the queue of scheduled messages that are not due yet.
+const QueueScheduled = "scheduled"
+
+// InstrumentEmail wraps s so that every send is counted and timed under the
+// given provider name.
+func (m *Metrics) InstrumentEmail(s delivery.EmailSender, provider string) delivery.EmailSender {
+	return &instrumentedEmail{next: s, m: m, provider: provider}
+}
+
+// InstrumentSms wraps s so that every send is counted and timed under the
+// given provider name.
+func (m *Metrics) InstrumentSms(s delivery.SmsSender, provider string) delivery.SmsSender {
+	return &instrumentedSms{next: s, m: m, provider: provider}
+}
+
+type instrumentedEmail struct {
+	next     delivery.EmailSender
+	m        *Metrics
+	provider string
+}
+
+func (i *instrumentedEmail) Send(ctx context.Context, email *message.Email) (messageID string, err error) {
+	err = i.m.observe(ChannelEmail, i.provider, func() error {
+		messageID, err = i.next.Send(ctx, email)
+		return err
+	})
+	return messageID, err
+}
+
+type instrumentedSms struct {
+	next     delivery.SmsSender
+	m        *Metrics
+	provider string
+}
+
+func (i *instrumentedSms) Send(ctx context.Context, from string, to []string, body string) error {
+	return i.m.observe(ChannelSms, i.provider, func() error {
+		return i.next.Send(ctx, from, to, body)
+	})
+}
+
+// SetQueueDepth records the number of messages waiting in a queue that the
+// instrumented senders do not see, such as the scheduled messages.
+func (m *Metrics) SetQueueDepth(queue string, n int) {
+	if m == nil {
+		return
+	}
+	m.queueDepth.WithLabelValues(queue).Set(float64(n))
+}
+
+// observe runs send while it is counted in the channel's queue depth, then
+// records its latency and outcome.
+func (m *Metrics) observe(channel, provider string, send func() error) error {
+	depth := m.queueDepth.WithLabelValues(channel)
+	depth.Inc()
+	defer depth.Dec()
+
+	start := time.Now()
+	err := send()
+	m.providerLatency.WithLabelValues(channel, provider).Observe(time.Since(start).Seconds())
+
+	outcome := "success"
+	if err != nil {
+		outcome = "failure"
+	}
+	m.deliveries.WithLabelValues(channel, provider, outcome).Inc()
+	return err
+}
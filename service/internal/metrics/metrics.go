@@ -0,0 +1,131 @@
+// Package metrics collects the Prometheus metrics served on /metrics. Each
+// server owns its own registry, so embedded instances do not collide.
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "mds"
+
+// Label values used when the real value is unknown.
+const (
+	anonymous = "anonymous"
+	unmatched = "unmatched"
+)
+
+// Metrics holds the service's collectors. A nil *Metrics records nothing.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	requests        *prometheus.CounterVec
+	deliveries      *prometheus.CounterVec
+	providerLatency *prometheus.HistogramVec
+	queueDepth      *prometheus.GaugeVec
+	authFailures    *prometheus.CounterVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by route pattern, authenticated client ID and status code.",
+		}, []string{"route", "client_id", "status"}),
+		deliveries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "deliveries_total",
+			Help:      "Delivery attempts by channel, provider and outcome.",
+		}, []string{"channel", "provider", "outcome"}),
+		providerLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "provider_latency_seconds",
+			Help:      "Time spent in provider calls.",
+			Buckets:   []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+		}, []string{"channel", "provider"}),
+		queueDepth: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "queue_depth",
+			Help:      "Messages waiting for or in delivery, by queue.",
+		}, []string{"queue"}),
+		authFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "auth_failures_total",
+			Help:      "Rejected authentication attempts by error code.",
+		}, []string{"reason"}),
+	}
+
+	m.registry.MustRegister(
+		m.requests, m.deliveries, m.providerLatency, m.queueDepth, m.authFailures,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return m
+}
+
+// Registry exposes the registry so callers can add their own collectors.
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+// Handler serves the metrics in the Prometheus text format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// AuthFailure counts a rejected request. reason is the apierror code.
+func (m *Metrics) AuthFailure(reason string) {
+	if m == nil {
+		return
+	}
+	m.authFailures.WithLabelValues(reason).Inc()
+}
+
+type requestInfoKey struct{}
+
+// requestInfo is filled in by handlers further down the chain and read back
+// by Middleware once the request completes.
+type requestInfo struct {
+	clientID string
+}
+
+// SetClientID records the authenticated client for the request's metrics.
+func SetClientID(ctx context.Context, id string) {
+	if info, ok := ctx.Value(requestInfoKey{}).(*requestInfo); ok {
+		info.clientID = id
+	}
+}
+
+// Middleware counts requests. Only authenticated client IDs become label
+// values, so unauthenticated callers cannot inflate cardinality.
+func (m *Metrics) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		info := &requestInfo{clientID: anonymous}
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		r = r.WithContext(context.WithValue(r.Context(), requestInfoKey{}, info))
+
+		next.ServeHTTP(ww, r)
+
+		route := unmatched
+		if rctx := chi.RouteContext(r.Context()); rctx != nil {
+			if pattern := rctx.RoutePattern(); pattern != "" && !strings.HasSuffix(pattern, "/*") {
+				route = pattern
+			}
+		}
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		m.requests.WithLabelValues(route, info.clientID, strconv.Itoa(status)).Inc()
+	})
+}
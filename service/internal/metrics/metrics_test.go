@@ -0,0 +1,86 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type fakeSms struct{ err error }
+
+func (f fakeSms) Send(ctx context.Context, from string, to []string, body string) error {
+	return f.err
+}
+
+func scrape(t *testing.T, m *Metrics) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Scrape failed with %d", rec.Code)
+	}
+	return rec.Body.String()
+}
+
+func expectLines(t *testing.T, out string, lines ...string) {
+	t.Helper()
+	for _, line := range lines {
+		if !strings.Contains(out, line) {
+			t.Errorf("Metrics output is missing %q", line)
+		}
+	}
+}
+
+func TestMiddleware_CountsRequests(t *testing.T) {
+	m := New()
+
+	r := chi.NewRouter()
+	r.Use(m.Middleware)
+	r.Post("/v3/sms", func(w http.ResponseWriter, r *http.Request) {
+		SetClientID(r.Context(), "billing")
+		w.WriteHeader(http.StatusAccepted)
+	})
+	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {})
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodPost, "/v3/sms", nil),
+		httptest.NewRequest(http.MethodGet, "/health", nil),
+		httptest.NewRequest(http.MethodGet, "/wp-admin/1", nil),
+	} {
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	expectLines(t, scrape(t, m),
+		`mds_http_requests_total{client_id="billing",route="/v3/sms",status="202"} 1`,
+		`mds_http_requests_total{client_id="anonymous",route="/health",status="200"} 1`,
+		`mds_http_requests_total{client_id="anonymous",route="unmatched",status="404"} 1`,
+	)
+}
+
+func TestInstrumentSms(t *testing.T) {
+	m := New()
+	ok := m.InstrumentSms(fakeSms{}, "46elks")
+	failing := m.InstrumentSms(fakeSms{err: errors.New("boom")}, "46elks")
+
+	ok.Send(context.Background(), "MDS", []string{"+46701234567"}, "hi")
+	ok.Send(context.Background(), "MDS", []string{"+46701234567"}, "hi")
+	if err := failing.Send(context.Background(), "MDS", []string{"+46701234567"}, "hi"); err == nil {
+		t.Fatal("Instrumented sender swallowed the error")
+	}
+	m.AuthFailure("SIGNATURE_INVALID")
+	m.SetQueueDepth(QueueScheduled, 3)
+
+	expectLines(t, scrape(t, m),
+		`mds_deliveries_total{channel="sms",outcome="success",provider="46elks"} 2`,
+		`mds_deliveries_total{channel="sms",outcome="failure",provider="46elks"} 1`,
+		`mds_provider_latency_seconds_count{channel="sms",provider="46elks"} 3`,
+		`mds_queue_depth{queue="sms"} 0`,
+		`mds_queue_depth{queue="scheduled"} 3`,
+		`mds_auth_failures_total{reason="SIGNATURE_INVALID"} 1`,
+	)
+}
@@ -0,0 +1,98 @@
+package quiethours
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/config"
+)
+
+func TestNext(t *testing.T) {
+	stockholm, err := time.LoadLocation("Europe/Stockholm")
+	if err != nil {
+		t.Fatalf("Failed to load time zone: %v", err)
+	}
+	night := config.QuietHoursConfig{Start: "21:00", End: "08:00"}
+	lunch := config.QuietHoursConfig{Start: "12:00", End: "13:00"}
+
+	tests := []struct {
+		name string
+		cfg  config.QuietHoursConfig
+		at   string
+		want string
+	}{
+		{"before the window", night, "2026-10-19T20:59:00+02:00", "2026-10-19T20:59:00+02:00"},
+		{"evening", night, "2026-10-19T21:00:00+02:00", "2026-10-20T08:00:00+02:00"},
+		{"after midnight", night, "2026-10-20T03:00:00+02:00", "2026-10-20T08:00:00+02:00"},
+		{"end of the window", night, "2026-10-20T08:00:00+02:00", "2026-10-20T08:00:00+02:00"},
+		{"across a DST change", night, "2026-10-24T23:30:00+02:00", "2026-10-25T08:00:00+01:00"},
+		{"daytime window", lunch, "2026-10-19T12:30:00+02:00", "2026-10-19T13:00:00+02:00"},
+		{"outside daytime window", lunch, "2026-10-19T13:00:00+02:00", "2026-10-19T13:00:00+02:00"},
+		{"disabled", config.QuietHoursConfig{}, "2026-10-20T03:00:00+02:00", "2026-10-20T03:00:00+02:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			at, _ := time.Parse(time.RFC3339, tt.at)
+			want, _ := time.Parse(time.RFC3339, tt.want)
+			if got := Next(tt.cfg, at, stockholm); !got.Equal(want) {
+				t.Errorf("Expected %s, got %s", want, got.In(stockholm))
+			}
+		})
+	}
+
+}
+
+func TestNextAll(t *testing.T) {
+	night := config.QuietHoursConfig{Start: "21:00", End: "08:00"}
+	tests := []struct {
+		name    string
+		country string
+		at      time.Time
+		want    time.Time
+	}{
+		// 03:00 UTC is 23:00 in New York and 17:00 in Honolulu; 08:00 in
+		// Honolulu is 18:00 UTC.
+		{"US", "us", time.Date(2026, 10, 20, 3, 0, 0, 0, time.UTC), time.Date(2026, 10, 20, 18, 0, 0, 0, time.UTC)},
+		// 05:00 UTC is 08:00 in Moscow but 17:00 in Kamchatka; the quiet
+		// hours end last in Kaliningrad, at 06:00 UTC.
+		{"RU", "RU", time.Date(2026, 10, 20, 5, 0, 0, 0, time.UTC), time.Date(2026, 10, 20, 6, 0, 0, 0, time.UTC)},
+		// 09:00 UTC is 21:00 in Kamchatka, and by 08:00 there the quiet
+		// hours have started in Kaliningrad.
+		{"RU evening in the east", "RU", time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC), time.Date(2026, 10, 21, 6, 0, 0, 0, time.UTC)},
+		{"single zone", "SE", time.Date(2026, 10, 20, 3, 0, 0, 0, time.UTC), time.Date(2026, 10, 20, 6, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locs, ok := CountryLocations(tt.country)
+			if !ok {
+				t.Fatalf("Unknown country %s", tt.country)
+			}
+			if got, ok := NextAll(night, tt.at, locs); !ok || !got.Equal(tt.want) {
+				t.Errorf("Expected %s, got %s (%v)", tt.want, got.UTC(), ok)
+			}
+		})
+	}
+
+	// A window too long for the zones of a country to share a time outside it.
+	locs, _ := CountryLocations("RU")
+	long := config.QuietHoursConfig{Start: "18:00", End: "10:00"}
+	if _, ok := NextAll(long, time.Date(2026, 10, 20, 3, 0, 0, 0, time.UTC), locs); ok {
+		t.Error("Expected no time outside the window in every Russian zone")
+	}
+}
+
+func TestCountryLocations(t *testing.T) {
+	for country := range countryZones {
+		if _, ok := CountryLocations(country); !ok {
+			t.Errorf("Time zones of %s do not load", country)
+		}
+	}
+	for country := range otherZones {
+		if _, ok := countryZones[country]; !ok {
+			t.Errorf("Country %s has other zones but no main one", country)
+		}
+	}
+	if _, ok := CountryLocations("ZZ"); ok {
+		t.Error("Expected no time zone for an unknown country")
+	}
+}
@@ -0,0 +1,283 @@
+// Package schedule keeps messages that are to be sent at a later time. Jobs
+// are persisted in storage, so pending messages survive restarts, and are
+// handed to a Dispatcher once they are due.
+package schedule
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/message"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/storage"
+)
+
+// bucket holds one record per pending job, keyed by "<due time>/<id>" so that
+// listing the bucket returns jobs in the order they are due.
+const bucket = "scheduled"
+
+// keyTime formats due times so that they sort lexically.
+const keyTime = "20060102T150405.000000000Z"
+
+const (
+	// MaxDelay is how far ahead a message can be scheduled.
+	MaxDelay = 365 * 24 * time.Hour
+
+	// MaxAttempts is how many times a due job is dispatched before it is
+	// dropped. Failed attempts are retried after RetryDelay, doubling each
+	// time.
+	MaxAttempts = 5
+	RetryDelay  = time.Minute
+
+	// PollInterval is how often Run checks for due jobs.
+	PollInterval = time.Second
+)
+
+// ErrNotFound is returned when cancelling a message that has no pending job,
+// because it is unknown, has already been sent or was cancelled.
+var ErrNotFound = errors.New("schedule: job not found")
+
+// Job is a message to send at SendAt. Exactly one of Email and SMS is set.
+// A message sent to recipients at different times, e.g. because of their
+// quiet hours, has one job per time, all with the message's ID.
+type Job struct {
+	ID        string    `json:"id"` // the message ID reported to the client
+	Channel   string    `json:"channel"`
+	Service   string    `json:"service"` // the client that scheduled it
+	Scope     string    `json:"scope,omitempty"`
+	SendAt    time.Time `json:"sendAt"`
+	CreatedAt time.Time `json:"createdAt"`
+	Attempts  int       `json:"attempts,omitempty"`
+	Detail    string    `json:"detail,omitempty"` // e.g. why it was deferred
+
+	Email *message.Email `json:"email,omitempty"`
+	SMS   *SMS           `json:"sms,omitempty"`
+}
+
+// SMS is the content of a scheduled SMS.
+type SMS struct {
+	Sender string   `json:"sender"`
+	To     []string `json:"to"`
+	Body   string   `json:"body"`
+}
+
+// Recipients returns the addresses or numbers the job sends to.
+func (j *Job) Recipients() []string {
+	switch {
+	case j.Email != nil:
+		return j.Email.Recipients()
+	case j.SMS != nil:
+		return j.SMS.To
+	}
+	return nil
+}
+
+// Dispatcher sends due jobs.
+type Dispatcher interface {
+	// Dispatch sends the job. Errors are retried until MaxAttempts with the
+	// job as Dispatch left it, so it can drop the recipients already sent to.
+	Dispatch(ctx context.Context, job *Job) error
+
+	// Fail is called when the job is dropped after its last attempt failed.
+	Fail(ctx context.Context, job *Job, err error)
+
+	// Cancel is called when the job is dropped because it was cancelled
+	// after it was claimed, instead of dispatching or retrying it.
+	Cancel(ctx context.Context, job *Job)
+}
+
+// Queue keeps scheduled jobs in storage. Jobs are claimed by deleting them
+// before they are dispatched, so a crash while sending drops the job rather
+// than sending it twice. Only one process should run a queue over the same
+// storage.
+type Queue struct {
+	storage storage.Storage
+	logger  *slog.Logger
+
+	mu        sync.Mutex      // serializes claims, cancellations and retries
+	claimed   map[string]int  // jobs claimed but not yet dispatched, by ID
+	cancelled map[string]bool // claimed IDs cancelled since
+	depth     func(pending int)
+}
+
+func NewQueue(st storage.Storage, logger *slog.Logger) *Queue {
+	return &Queue{storage: st, logger: logger, claimed: make(map[string]int), cancelled: make(map[string]bool)}
+}
+
+// ReportDepth makes every poll of Run call f with the number of jobs that are
+// not due yet, e.g. to export the queue's depth as a metric.
+func (q *Queue) ReportDepth(f func(pending int)) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.depth = f
+}
+
+// NewID returns a unique ID for a job that has no message ID of its own.
+func NewID() (string, error) {
+	var random [12]byte
+	if _, err := rand.Read(random[:]); err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(time.Now().UnixNano(), 36) + "." + hex.EncodeToString(random[:]), nil
+}
+
+// Add schedules job, replacing a pending job with the same ID and due time.
+func (q *Queue) Add(ctx context.Context, job *Job) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	return q.storage.Put(ctx, bucket, key(job.SendAt, job.ID), data)
+}
+
+// Cancel removes the pending jobs with the given ID and returns them, or
+// ErrNotFound when there are none. Jobs with the ID that are being dispatched
+// are not returned, but are dropped instead of sent or retried, and handed to
+// the Dispatcher's Cancel; one that is already being sent may still succeed.
+func (q *Queue) Cancel(ctx context.Context, id string) ([]*Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	items, err := q.storage.List(ctx, bucket, "")
+	if err != nil {
+		return nil, err
+	}
+	var jobs []*Job
+	for _, item := range items {
+		if _, itemID, _ := strings.Cut(item.Key, "/"); itemID != id {
+			continue
+		}
+		var job Job
+		if err := json.Unmarshal(item.Value, &job); err != nil {
+			return jobs, err
+		}
+		if err := q.storage.Delete(ctx, bucket, item.Key); err != nil {
+			return jobs, err
+		}
+		jobs = append(jobs, &job)
+	}
+	if q.claimed[id] > 0 {
+		q.cancelled[id] = true
+	} else if len(jobs) == 0 {
+		return nil, ErrNotFound
+	}
+	return jobs, nil
+}
+
+// Run dispatches due jobs until ctx is cancelled. Jobs that fell due while
+// the service was down are dispatched on the first poll.
+func (q *Queue) Run(ctx context.Context, d Dispatcher) {
+	for {
+		jobs, err := q.claim(ctx, time.Now())
+		if err != nil {
+			q.logger.WarnContext(ctx, "Failed to read scheduled messages", "error", err)
+		}
+		for _, job := range jobs {
+			q.dispatch(ctx, d, job)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(PollInterval):
+		}
+	}
+}
+
+// claim removes and returns the jobs due at now. Each one must be passed to
+// dispatch.
+func (q *Queue) claim(ctx context.Context, now time.Time) ([]*Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	items, err := q.storage.List(ctx, bucket, "")
+	if err != nil {
+		return nil, err
+	}
+	due := now.UTC().Format(keyTime)
+	n := 0
+	for n < len(items) && items[n].Key <= due {
+		n++
+	}
+	if q.depth != nil {
+		q.depth(len(items) - n)
+	}
+
+	var jobs []*Job
+	for _, item := range items[:n] {
+		if err := q.storage.Delete(ctx, bucket, item.Key); err != nil {
+			return jobs, err
+		}
+		var job Job
+		if err := json.Unmarshal(item.Value, &job); err != nil {
+			q.logger.WarnContext(ctx, "Dropping unreadable scheduled message", "key", item.Key, "error", err)
+			continue
+		}
+		q.claimed[job.ID]++
+		jobs = append(jobs, &job)
+	}
+	return jobs, nil
+}
+
+// dispatch sends a claimed job, scheduling a retry when it fails. Jobs
+// cancelled since they were claimed are dropped instead.
+func (q *Queue) dispatch(ctx context.Context, d Dispatcher, job *Job) {
+	// 1. Send
+	q.mu.Lock()
+	cancelled := q.cancelled[job.ID]
+	q.mu.Unlock()
+	var err error
+	if !cancelled {
+		if err = d.Dispatch(ctx, job); err == nil {
+			q.mu.Lock()
+			q.unclaimLocked(job.ID)
+			q.mu.Unlock()
+			return
+		}
+	}
+
+	// 2. Retry, under the same lock as cancellations, so that a job
+	// cancelled while it was being sent is not added back.
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.unclaimLocked(job.ID) {
+		q.logger.InfoContext(ctx, "Dropping cancelled scheduled message", "message_id", job.ID)
+		d.Cancel(ctx, job)
+		return
+	}
+	job.Attempts++
+	if job.Attempts >= MaxAttempts {
+		q.logger.ErrorContext(ctx, "Scheduled message failed", "message_id", job.ID, "attempts", job.Attempts, "error", err)
+		d.Fail(ctx, job, err)
+		return
+	}
+	job.SendAt = time.Now().Add(RetryDelay << (job.Attempts - 1))
+	q.logger.WarnContext(ctx, "Scheduled message failed, retrying", "message_id", job.ID,
+		"attempts", job.Attempts, "retry_at", job.SendAt, "error", err)
+	if err := q.Add(ctx, job); err != nil {
+		q.logger.ErrorContext(ctx, "Failed to reschedule message", "message_id", job.ID, "error", err)
+		d.Fail(ctx, job, err)
+	}
+}
+
+// unclaimLocked releases a claimed job and reports whether its ID was
+// cancelled since it was claimed.
+func (q *Queue) unclaimLocked(id string) bool {
+	cancelled := q.cancelled[id]
+	if q.claimed[id]--; q.claimed[id] <= 0 {
+		delete(q.claimed, id)
+		delete(q.cancelled, id)
+	}
+	return cancelled
+}
+
+func key(sendAt time.Time, id string) string {
+	return sendAt.UTC().Format(keyTime) + "/" + id
+}
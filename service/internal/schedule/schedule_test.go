@@ -0,0 +1,159 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/storage"
+)
+
+var quiet = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+type fakeDispatcher struct {
+	err       error
+	during    func() // called while sending
+	sent      []string
+	failed    []string
+	cancelled []string
+}
+
+func (d *fakeDispatcher) Dispatch(ctx context.Context, job *Job) error {
+	if d.during != nil {
+		d.during()
+	}
+	d.sent = append(d.sent, job.ID)
+	return d.err
+}
+
+func (d *fakeDispatcher) Fail(ctx context.Context, job *Job, err error) {
+	d.failed = append(d.failed, job.ID)
+}
+
+func (d *fakeDispatcher) Cancel(ctx context.Context, job *Job) {
+	d.cancelled = append(d.cancelled, job.ID)
+}
+
+func TestQueue_ClaimsDueJobsAfterRestart(t *testing.T) {
+	ctx := context.Background()
+	st := storage.NewMemory()
+	now := time.Now()
+	q := NewQueue(st, quiet)
+	for _, job := range []*Job{
+		{ID: "later", Channel: "sms", SendAt: now.Add(time.Hour), SMS: &SMS{To: []string{"+46700000000"}}},
+		{ID: "due", Channel: "sms", SendAt: now.Add(-time.Minute), SMS: &SMS{To: []string{"+46700000001"}}},
+		{ID: "first", Channel: "sms", SendAt: now.Add(-time.Hour), SMS: &SMS{To: []string{"+46700000002"}}},
+	} {
+		if err := q.Add(ctx, job); err != nil {
+			t.Fatalf("Failed to add %s: %v", job.ID, err)
+		}
+	}
+
+	// A new queue over the same storage picks up the pending jobs.
+	q = NewQueue(st, quiet)
+	pending := -1
+	q.ReportDepth(func(n int) { pending = n })
+	jobs, err := q.claim(ctx, now)
+	if err != nil {
+		t.Fatalf("Failed to claim: %v", err)
+	}
+	if pending != 1 {
+		t.Errorf("Expected one job not due yet, got %d", pending)
+	}
+	if len(jobs) != 2 || jobs[0].ID != "first" || jobs[1].ID != "due" || jobs[1].SMS.To[0] != "+46700000001" {
+		t.Fatalf("Unexpected due jobs: %+v", jobs)
+	}
+	if jobs, _ := q.claim(ctx, now); len(jobs) != 0 {
+		t.Errorf("Claimed jobs were not removed: %+v", jobs)
+	}
+	for _, job := range jobs {
+		q.dispatch(ctx, &fakeDispatcher{}, job)
+	}
+
+	q.Add(ctx, &Job{ID: "later", Channel: "sms", SendAt: now.Add(2 * time.Hour), SMS: &SMS{To: []string{"+46700000003"}}})
+	if jobs, err := q.Cancel(ctx, "later"); err != nil || len(jobs) != 2 || jobs[0].SMS.To[0] != "+46700000000" {
+		t.Fatalf("Unexpected cancel result: %+v, %v", jobs, err)
+	}
+	if _, err := q.Cancel(ctx, "later"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a cancelled job, got %v", err)
+	}
+	if _, err := q.Cancel(ctx, "due"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a dispatched job, got %v", err)
+	}
+}
+
+func TestQueue_RetriesFailedDispatch(t *testing.T) {
+	ctx := context.Background()
+	q := NewQueue(storage.NewMemory(), quiet)
+	d := &fakeDispatcher{err: errors.New("provider down")}
+
+	job := &Job{ID: "retry", Channel: "sms", SendAt: time.Now()}
+	q.dispatch(ctx, d, job)
+	if job.Attempts != 1 || len(d.failed) != 0 {
+		t.Fatalf("Expected a retry, got %d attempts and failures %v", job.Attempts, d.failed)
+	}
+	if jobs, _ := q.claim(ctx, time.Now()); len(jobs) != 0 {
+		t.Errorf("Retry is due too early: %+v", jobs)
+	}
+	jobs, _ := q.claim(ctx, time.Now().Add(RetryDelay+time.Second))
+	if len(jobs) != 1 || jobs[0].Attempts != 1 {
+		t.Fatalf("Expected the job to be retried after %v, got %+v", RetryDelay, jobs)
+	}
+
+	job.Attempts = MaxAttempts - 1
+	q.dispatch(ctx, d, job)
+	if len(d.failed) != 1 {
+		t.Errorf("Expected the job to fail after %d attempts", MaxAttempts)
+	}
+	if jobs, _ := q.claim(ctx, time.Now().Add(MaxDelay)); len(jobs) != 0 {
+		t.Errorf("Failed job was rescheduled: %+v", jobs)
+	}
+}
+
+func TestQueue_CancelsClaimedJobs(t *testing.T) {
+	ctx := context.Background()
+	q := NewQueue(storage.NewMemory(), quiet)
+	claim := func(id string) *Job {
+		t.Helper()
+		if err := q.Add(ctx, &Job{ID: id, Channel: "sms", SendAt: time.Now()}); err != nil {
+			t.Fatalf("Failed to add %s: %v", id, err)
+		}
+		jobs, err := q.claim(ctx, time.Now())
+		if err != nil || len(jobs) != 1 {
+			t.Fatalf("Failed to claim %s: %+v, %v", id, jobs, err)
+		}
+		return jobs[0]
+	}
+
+	// 1. Cancelled before it is dispatched
+	job := claim("waiting")
+	if _, err := q.Cancel(ctx, "waiting"); err != nil {
+		t.Fatalf("Failed to cancel a claimed job: %v", err)
+	}
+	d := &fakeDispatcher{}
+	q.dispatch(ctx, d, job)
+	if len(d.sent) != 0 || len(d.cancelled) != 1 {
+		t.Errorf("Expected the job to be dropped, sent %v, cancelled %v", d.sent, d.cancelled)
+	}
+	if _, err := q.Cancel(ctx, "waiting"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound once dispatched, got %v", err)
+	}
+
+	// 2. Cancelled while a failing attempt is made
+	job = claim("sending")
+	d = &fakeDispatcher{err: errors.New("provider down"), during: func() {
+		if _, err := q.Cancel(ctx, "sending"); err != nil {
+			t.Errorf("Failed to cancel a job being sent: %v", err)
+		}
+	}}
+	q.dispatch(ctx, d, job)
+	if len(d.sent) != 1 || len(d.cancelled) != 1 || len(d.failed) != 0 {
+		t.Errorf("Expected the failed job to be dropped, sent %v, cancelled %v, failed %v", d.sent, d.cancelled, d.failed)
+	}
+	if jobs, _ := q.claim(ctx, time.Now().Add(MaxDelay)); len(jobs) != 0 {
+		t.Errorf("Cancelled job was rescheduled: %+v", jobs)
+	}
+}
@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// Bolt is a Storage in a single bbolt file, which keeps its contents across
+// restarts. Only one process can open the file at a time.
+type Bolt struct {
+	db *bolt.DB
+}
+
+// OpenBolt opens the database at path, creating it and its directory if
+// needed. It fails if another process holds the file for more than a second.
+func OpenBolt(path string) (*Bolt, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+	return &Bolt{db: db}, nil
+}
+
+func (b *Bolt) Get(ctx context.Context, bucket, key string) ([]byte, error) {
+	var value []byte
+	err := b.db.View(func(tx *bolt.Tx) error {
+		bk := tx.Bucket([]byte(bucket))
+		if bk == nil {
+			return ErrNotFound
+		}
+		v := bk.Get([]byte(key))
+		if v == nil {
+			return ErrNotFound
+		}
+		// Values are only valid during the transaction.
+		value = append([]byte(nil), v...)
+		return nil
+	})
+	return value, err
+}
+
+func (b *Bolt) Put(ctx context.Context, bucket, key string, value []byte) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bk, err := tx.CreateBucketIfNotExists([]byte(bucket))
+		if err != nil {
+			return err
+		}
+		return bk.Put([]byte(key), value)
+	})
+}
+
+func (b *Bolt) Delete(ctx context.Context, bucket, key string) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bk := tx.Bucket([]byte(bucket))
+		if bk == nil {
+			return nil
+		}
+		return bk.Delete([]byte(key))
+	})
+}
+
+func (b *Bolt) List(ctx context.Context, bucket, prefix string) ([]Item, error) {
+	var items []Item
+	err := b.db.View(func(tx *bolt.Tx) error {
+		bk := tx.Bucket([]byte(bucket))
+		if bk == nil {
+			return nil
+		}
+		// Keys are kept sorted, so the matches follow the first one.
+		c := bk.Cursor()
+		for k, v := c.Seek([]byte(prefix)); k != nil && bytes.HasPrefix(k, []byte(prefix)); k, v = c.Next() {
+			items = append(items, Item{Key: string(k), Value: append([]byte(nil), v...)})
+		}
+		return nil
+	})
+	return items, err
+}
+
+func (b *Bolt) Close() error {
+	return b.db.Close()
+}
@@ -0,0 +1,29 @@
+package storage
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestBolt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", "mds.db")
+	b, err := OpenBolt(path)
+	if err != nil {
+		t.Fatalf("Failed to open: %v", err)
+	}
+	testStorage(t, b)
+	if err := b.Close(); err != nil {
+		t.Fatalf("Failed to close: %v", err)
+	}
+
+	// The contents survive reopening the file.
+	b, err = OpenBolt(path)
+	if err != nil {
+		t.Fatalf("Failed to reopen: %v", err)
+	}
+	defer b.Close()
+	if value, err := b.Get(context.Background(), "b", "user:2"); err != nil || string(value) != "user:2" {
+		t.Errorf("Unexpected value after reopening %q, err %v", value, err)
+	}
+}
@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Memory is an in-process Storage. Its contents are lost on restart.
+type Memory struct {
+	mu      sync.RWMutex
+	buckets map[string]map[string][]byte
+}
+
+func NewMemory() *Memory {
+	return &Memory{buckets: make(map[string]map[string][]byte)}
+}
+
+func (m *Memory) Get(ctx context.Context, bucket, key string) ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	value, ok := m.buckets[bucket][key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return append([]byte(nil), value...), nil
+}
+
+func (m *Memory) Put(ctx context.Context, bucket, key string, value []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	b, ok := m.buckets[bucket]
+	if !ok {
+		b = make(map[string][]byte)
+		m.buckets[bucket] = b
+	}
+	b[key] = append([]byte(nil), value...)
+	return nil
+}
+
+func (m *Memory) Delete(ctx context.Context, bucket, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.buckets[bucket], key)
+	return nil
+}
+
+func (m *Memory) List(ctx context.Context, bucket, prefix string) ([]Item, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var items []Item
+	for key, value := range m.buckets[bucket] {
+		if strings.HasPrefix(key, prefix) {
+			items = append(items, Item{Key: key, Value: append([]byte(nil), value...)})
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Key < items[j].Key })
+	return items, nil
+}
+
+func (m *Memory) Close() error {
+	return nil
+}
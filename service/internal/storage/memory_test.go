@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// testStorage checks the behavior every Storage shares.
+func testStorage(t *testing.T, st Storage) {
+	t.Helper()
+	ctx := context.Background()
+
+	if _, err := st.Get(ctx, "b", "missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Expected ErrNotFound, got %v", err)
+	}
+
+	for _, key := range []string{"user:2", "user:1", "other"} {
+		if err := st.Put(ctx, "b", key, []byte(key)); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+	}
+	st.Put(ctx, "elsewhere", "user:3", []byte("x"))
+
+	items, err := st.List(ctx, "b", "user:")
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(items) != 2 || items[0].Key != "user:1" || items[1].Key != "user:2" {
+		t.Errorf("Unexpected items: %+v", items)
+	}
+	if items, err := st.List(ctx, "none", ""); err != nil || len(items) != 0 {
+		t.Errorf("Expected an empty bucket, got %+v, %v", items, err)
+	}
+
+	if err := st.Delete(ctx, "b", "user:1"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := st.Get(ctx, "b", "user:1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Deleted key still present: %v", err)
+	}
+	if value, err := st.Get(ctx, "b", "user:2"); err != nil || string(value) != "user:2" {
+		t.Errorf("Unexpected value %q, err %v", value, err)
+	}
+}
+
+func TestMemory(t *testing.T) {
+	testStorage(t, NewMemory())
+}
@@ -0,0 +1,32 @@
+// Package storage persists service state as opaque values grouped into
+// buckets. Features that need to remember something between requests keep
+// their records here, so that embedders can swap the backend.
+package storage
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound is returned by Get when the key does not exist.
+var ErrNotFound = errors.New("storage: not found")
+
+// Item is a stored key and its value.
+type Item struct {
+	Key   string
+	Value []byte
+}
+
+// Storage is a bucketed key-value store. Implementations must be safe for
+// concurrent use.
+type Storage interface {
+	Get(ctx context.Context, bucket, key string) ([]byte, error)
+	Put(ctx context.Context, bucket, key string, value []byte) error
+	Delete(ctx context.Context, bucket, key string) error
+
+	// List returns the items in bucket whose key starts with prefix, sorted
+	// by key.
+	List(ctx context.Context, bucket, prefix string) ([]Item, error)
+
+	Close() error
+}
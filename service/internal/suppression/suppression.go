@@ -0,0 +1,170 @@
+// Package suppression keeps the recipients that must no longer be sent to,
+// such as addresses that hard-bounced or unsubscribed. Entries apply within a
+// scope: ScopeGlobal blocks every send, other scopes (e.g. "newsletter") only
+// the sends made in that scope, by any service.
+package suppression
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/storage"
+)
+
+// bucket holds one entry per suppressed recipient and scope, keyed by
+// "<scope>/<recipient>".
+const bucket = "suppressions"
+
+// ScopeGlobal suppresses a recipient for every send.
+const ScopeGlobal = "global"
+
+// Reasons for suppressing a recipient.
+const (
+	ReasonHardBounce  = "HARD_BOUNCE"
+	ReasonComplaint   = "COMPLAINT"
+	ReasonUnsubscribe = "UNSUBSCRIBE"
+	ReasonManual      = "MANUAL"
+)
+
+// ErrNotFound is returned when removing a recipient that is not suppressed.
+var ErrNotFound = errors.New("suppression: entry not found")
+
+// Entry is a suppressed recipient: an email address or a phone number.
+type Entry struct {
+	Recipient string    `json:"recipient"`
+	Scope     string    `json:"scope"`
+	Reason    string    `json:"reason"`
+	Detail    string    `json:"detail,omitempty"`
+	Service   string    `json:"service,omitempty"` // the client that added it, if any
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// List keeps suppression entries in storage.
+type List struct {
+	storage storage.Storage
+}
+
+func NewList(st storage.Storage) *List {
+	return &List{storage: st}
+}
+
+// Add suppresses e.Recipient in e.Scope (default ScopeGlobal), replacing any
+// existing entry. The recipient is stored normalized.
+func (l *List) Add(ctx context.Context, e Entry) error {
+	e.Recipient, e.Scope = Normalize(e.Recipient), scopeOrGlobal(e.Scope)
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = time.Now().UTC()
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return l.storage.Put(ctx, bucket, key(e.Scope, e.Recipient), data)
+}
+
+// Get returns the entry for recipient in scope, if suppressed.
+func (l *List) Get(ctx context.Context, recipient, scope string) (*Entry, bool, error) {
+	data, err := l.storage.Get(ctx, bucket, key(scopeOrGlobal(scope), Normalize(recipient)))
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	var e Entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, false, err
+	}
+	return &e, true, nil
+}
+
+// Check returns the entry that blocks a send to recipient in scope: a global
+// entry, or else one in scope. scope may be empty for unscoped sends.
+func (l *List) Check(ctx context.Context, recipient, scope string) (*Entry, bool, error) {
+	e, ok, err := l.Get(ctx, recipient, ScopeGlobal)
+	if ok || err != nil || scope == "" || scope == ScopeGlobal {
+		return e, ok, err
+	}
+	return l.Get(ctx, recipient, scope)
+}
+
+// Remove lifts the suppression of recipient in scope.
+func (l *List) Remove(ctx context.Context, recipient, scope string) error {
+	k := key(scopeOrGlobal(scope), Normalize(recipient))
+	if _, err := l.storage.Get(ctx, bucket, k); errors.Is(err, storage.ErrNotFound) {
+		return ErrNotFound
+	} else if err != nil {
+		return err
+	}
+	return l.storage.Delete(ctx, bucket, k)
+}
+
+// List returns the entries in scope, or in every scope when scope is empty,
+// sorted by scope and recipient.
+func (l *List) List(ctx context.Context, scope string) ([]Entry, error) {
+	prefix := ""
+	if scope != "" {
+		prefix = scope + "/"
+	}
+	items, err := l.storage.List(ctx, bucket, prefix)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]Entry, 0, len(items))
+	for _, item := range items {
+		var e Entry
+		if err := json.Unmarshal(item.Value, &e); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// Normalize returns the form recipients are stored and matched in: email
+// addresses in lower case, phone numbers without spaces, dashes, dots or
+// parentheses.
+func Normalize(recipient string) string {
+	recipient = strings.TrimSpace(recipient)
+	if strings.Contains(recipient, "@") {
+		return strings.ToLower(recipient)
+	}
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '(', ')':
+			return -1
+		}
+		return r
+	}, recipient)
+}
+
+// ValidScope reports whether scope can name a suppression scope: 1 to 64
+// letters, digits, dots, dashes or underscores.
+func ValidScope(scope string) bool {
+	if len(scope) == 0 || len(scope) > 64 {
+		return false
+	}
+	for _, c := range scope {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '.', c == '-', c == '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func key(scope, recipient string) string {
+	return scope + "/" + recipient
+}
+
+func scopeOrGlobal(scope string) string {
+	if scope == "" {
+		return ScopeGlobal
+	}
+	return scope
+}
@@ -0,0 +1,117 @@
+package suppression
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/storage"
+)
+
+func TestList_Scopes(t *testing.T) {
+	ctx := context.Background()
+	l := NewList(storage.NewMemory())
+	for _, e := range []Entry{
+		{Recipient: "Gone@Example.com", Reason: ReasonHardBounce},
+		{Recipient: "+46 70-000 00 00", Scope: "newsletter", Reason: ReasonUnsubscribe},
+	} {
+		if err := l.Add(ctx, e); err != nil {
+			t.Fatalf("Failed to add %s: %v", e.Recipient, err)
+		}
+	}
+
+	tests := []struct {
+		recipient, scope string
+		suppressed       bool
+	}{
+		{"gone@example.com", "", true},
+		{"gone@example.com", "newsletter", true},
+		{"+46700000000", "newsletter", true},
+		{"+46700000000", "", false},
+		{"+46700000000", "billing", false},
+	}
+	for _, tt := range tests {
+		if _, ok, err := l.Check(ctx, tt.recipient, tt.scope); err != nil || ok != tt.suppressed {
+			t.Errorf("Check(%s, %q): expected %v, got %v, %v", tt.recipient, tt.scope, tt.suppressed, ok, err)
+		}
+	}
+
+	entries, err := l.List(ctx, "")
+	if err != nil || len(entries) != 2 || entries[0].Recipient != "gone@example.com" || entries[0].Scope != ScopeGlobal {
+		t.Errorf("Unexpected entries: %+v, %v", entries, err)
+	}
+	if err := l.Remove(ctx, "+46700000000", ""); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound outside the entry's scope, got %v", err)
+	}
+	if err := l.Remove(ctx, "+46700000000", "newsletter"); err != nil {
+		t.Errorf("Remove failed: %v", err)
+	}
+}
+
+func TestList_SurvivesReopen(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "mds.db")
+	st, err := storage.OpenBolt(path)
+	if err != nil {
+		t.Fatalf("Failed to open storage: %v", err)
+	}
+	entries := []Entry{
+		{Recipient: "gone@example.com", Reason: ReasonHardBounce},
+		{Recipient: "angry@example.com", Reason: ReasonComplaint},
+		{Recipient: "reader@example.com", Scope: "newsletter", Reason: ReasonUnsubscribe},
+	}
+	for _, e := range entries {
+		if err := NewList(st).Add(ctx, e); err != nil {
+			t.Fatalf("Failed to add %s: %v", e.Recipient, err)
+		}
+	}
+	if err := st.Close(); err != nil {
+		t.Fatalf("Failed to close storage: %v", err)
+	}
+
+	st, err = storage.OpenBolt(path)
+	if err != nil {
+		t.Fatalf("Failed to reopen storage: %v", err)
+	}
+	defer st.Close()
+	l := NewList(st)
+	for _, e := range entries {
+		got, ok, err := l.Check(ctx, e.Recipient, e.Scope)
+		if err != nil || !ok || got.Reason != e.Reason {
+			t.Errorf("Expected %s to stay suppressed for %s, got %+v, %v", e.Recipient, e.Reason, got, err)
+		}
+	}
+}
+
+func TestUnsubscribeToken(t *testing.T) {
+	token, err := UnsubscribeToken("secret", "User@Example.com", "newsletter")
+	if err != nil {
+		t.Fatalf("Failed to make token: %v", err)
+	}
+	recipient, scope, err := ParseUnsubscribeToken("secret", token)
+	if err != nil || recipient != "user@example.com" || scope != "newsletter" {
+		t.Fatalf("Unexpected result: %q, %q, %v", recipient, scope, err)
+	}
+
+	// The token does not reveal the recipient, even once decoded.
+	raw, _ := base64.RawURLEncoding.DecodeString(token)
+	if strings.Contains(token, "user") || strings.Contains(string(raw), "user@example.com") {
+		t.Errorf("Expected an opaque token, got %q", token)
+	}
+
+	tampered := []byte(token)
+	tampered[len(tampered)/2] ^= 1
+	for name, tt := range map[string]struct{ secret, token string }{
+		"other secret": {"other", token},
+		"tampered":     {"secret", string(tampered)},
+		"malformed":    {"secret", "not-a-token"},
+		"short":        {"secret", "AAAA"},
+	} {
+		if _, _, err := ParseUnsubscribeToken(tt.secret, tt.token); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("%s: expected ErrInvalidToken, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package suppression
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+// ErrInvalidToken is returned for unsubscribe tokens that are malformed or
+// not made with the configured secret.
+var ErrInvalidToken = errors.New("suppression: invalid unsubscribe token")
+
+// UnsubscribeToken returns the token of a one-click unsubscribe link that
+// suppresses recipient in scope. The recipient and scope are encrypted with a
+// key derived from secret, so the token is opaque in access logs and traces
+// and links cannot be made for other recipients. Tokens do not expire, since
+// links in old messages must keep working.
+func UnsubscribeToken(secret, recipient, scope string) (string, error) {
+	aead, err := tokenAEAD(secret)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	payload := []byte(scopeOrGlobal(scope) + "\n" + Normalize(recipient))
+	return base64.RawURLEncoding.EncodeToString(aead.Seal(nonce, nonce, payload, nil)), nil
+}
+
+// ParseUnsubscribeToken decrypts token and returns the recipient and scope
+// it unsubscribes.
+func ParseUnsubscribeToken(secret, token string) (recipient, scope string, err error) {
+	aead, err := tokenAEAD(secret)
+	if err != nil {
+		return "", "", err
+	}
+	sealed, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", "", ErrInvalidToken
+	}
+	payload, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return "", "", ErrInvalidToken
+	}
+	scope, recipient, ok := strings.Cut(string(payload), "\n")
+	if !ok || recipient == "" || !ValidScope(scope) {
+		return "", "", ErrInvalidToken
+	}
+	return recipient, scope, nil
+}
+
+// tokenAEAD returns AES-256-GCM keyed with a key derived from secret.
+func tokenAEAD(secret string) (cipher.AEAD, error) {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte("unsubscribe"))
+	block, err := aes.NewCipher(h.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,64 @@
+package tracing
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/logging"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.43.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RequestIDKey is the span attribute carrying chi's request ID, so traces
+// and logs of a request can be matched up.
+const RequestIDKey = "mds.request_id"
+
+// Middleware starts a server span for every request, continuing the trace of
+// an incoming traceparent header. It must run after chi's RequestID
+// middleware so the span carries the request ID.
+func Middleware(tp trace.TracerProvider) func(http.Handler) http.Handler {
+	tracer := Tracer(tp)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := Propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+			ctx, span := tracer.Start(ctx, r.Method,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					semconv.HTTPRequestMethodKey.String(r.Method),
+					attribute.String(RequestIDKey, middleware.GetReqID(r.Context())),
+				),
+			)
+			defer span.End()
+
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			// The route is only known once chi has matched the request. Paths
+			// carry recipients (/v3/suppressions/{recipient}) and unsubscribe
+			// tokens, so the span records the route pattern in their place and
+			// masks the path of requests that match no route.
+			path := logging.MaskText(r.URL.Path)
+			if rctx := chi.RouteContext(ctx); rctx != nil {
+				if pattern := rctx.RoutePattern(); pattern != "" && !strings.HasSuffix(pattern, "/*") {
+					span.SetName(r.Method + " " + pattern)
+					span.SetAttributes(semconv.HTTPRoute(pattern))
+					path = pattern
+				}
+			}
+			span.SetAttributes(semconv.URLPath(path))
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+			if status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(status))
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// Package tracing sets up OpenTelemetry tracing. Spans below the HTTP
+// middleware are started from the tracer provider of the span in the
+// context, so packages need no tracer of their own: without a traced
+// request their spans are no-ops.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/config"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/logging"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.43.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// DefaultServiceName is reported when tracing.service_name is not set.
+const DefaultServiceName = "message-delivery-service"
+
+const instrumentationName = "github.com/Low-Stack-Technologies/message-delivery-service"
+
+// Propagator reads and writes the W3C traceparent, tracestate and baggage
+// headers.
+var Propagator propagation.TextMapPropagator = propagation.NewCompositeTextMapPropagator(
+	propagation.TraceContext{}, propagation.Baggage{},
+)
+
+// NewProvider builds the tracer provider for cfg. When tracing is disabled
+// it returns a no-op provider. shutdown flushes buffered spans and must be
+// called before the process exits.
+func NewProvider(ctx context.Context, cfg config.TracingConfig) (tp trace.TracerProvider, shutdown func(context.Context) error, err error) {
+	if !cfg.Enabled {
+		return noop.NewTracerProvider(), func(context.Context) error { return nil }, nil
+	}
+
+	var opts []otlptracehttp.Option
+	if cfg.Endpoint != "" {
+		opts = append(opts, otlptracehttp.WithEndpointURL(cfg.Endpoint))
+	}
+	if len(cfg.Headers) > 0 {
+		headers := make(map[string]string, len(cfg.Headers))
+		for k, v := range cfg.Headers {
+			headers[k] = v.Value()
+		}
+		opts = append(opts, otlptracehttp.WithHeaders(headers))
+	}
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+
+	name := cfg.ServiceName
+	if name == "" {
+		name = DefaultServiceName
+	}
+	res, err := resource.New(ctx,
+		resource.WithTelemetrySDK(),
+		resource.WithFromEnv(),
+		resource.WithAttributes(semconv.ServiceName(name)),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build trace resource: %w", err)
+	}
+
+	ratio := 1.0
+	if cfg.SampleRatio != nil {
+		ratio = *cfg.SampleRatio
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+	)
+	return provider, provider.Shutdown, nil
+}
+
+// Tracer returns the service's tracer from tp.
+func Tracer(tp trace.TracerProvider) trace.Tracer {
+	return tp.Tracer(instrumentationName)
+}
+
+// Start starts a child of the span in ctx using that span's provider.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	tp := trace.SpanFromContext(ctx).TracerProvider()
+	return Tracer(tp).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records err, if any, on span and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		Fail(span, err)
+	}
+	span.End()
+}
+
+// Fail marks span as failed with err. Like log messages, the error text is
+// masked since provider errors often quote the recipient.
+func Fail(span trace.Span, err error) {
+	msg := logging.MaskText(err.Error())
+	span.RecordError(errors.New(msg))
+	span.SetStatus(codes.Error, msg)
+}
@@ -0,0 +1,96 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.43.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestMiddleware_ContinuesIncomingTrace(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	r := chi.NewRouter()
+	r.Use(Middleware(tp))
+	r.Post("/v3/email", func(w http.ResponseWriter, r *http.Request) {
+		_, span := Start(r.Context(), "queue email")
+		End(span, errors.New("550 mailbox bob@example.org unavailable"))
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/v3/email", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("Expected 2 spans, got %d", len(spans))
+	}
+	child, server := spans[0], spans[1]
+
+	if server.Name() != "POST /v3/email" || server.SpanKind() != trace.SpanKindServer {
+		t.Errorf("Unexpected server span %q (%v)", server.Name(), server.SpanKind())
+	}
+	if got := server.SpanContext().TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("Expected the incoming trace to continue, got trace %s", got)
+	}
+	if got := server.Parent().SpanID().String(); got != "00f067aa0ba902b7" {
+		t.Errorf("Expected the caller's span as parent, got %s", got)
+	}
+	if server.Status().Code != codes.Error {
+		t.Errorf("Expected a 500 to mark the span as failed, got %v", server.Status())
+	}
+
+	if child.Parent().SpanID() != server.SpanContext().SpanID() {
+		t.Error("Expected Start to create a child of the request span")
+	}
+	if child.Status().Description != "550 mailbox b***@example.org unavailable" {
+		t.Errorf("Expected a masked error status, got %q", child.Status().Description)
+	}
+}
+
+func TestMiddleware_RecordsRouteNotPath(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	r := chi.NewRouter()
+	r.Use(Middleware(tp))
+	r.Delete("/v3/suppressions/{recipient}", func(w http.ResponseWriter, r *http.Request) {})
+
+	for _, path := range []string{"/v3/suppressions/bob@example.org", "/unknown/bob@example.org"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodDelete, path, nil))
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("Expected 2 spans, got %d", len(spans))
+	}
+	for i, want := range []string{"/v3/suppressions/{recipient}", "/unknown/b***@example.org"} {
+		var got string
+		for _, attr := range spans[i].Attributes() {
+			if attr.Key == semconv.URLPathKey {
+				got = attr.Value.AsString()
+			}
+		}
+		if got != want {
+			t.Errorf("Expected url.path %q, got %q", want, got)
+		}
+	}
+}
+
+func TestStart_WithoutSpanIsNoop(t *testing.T) {
+	_, span := Start(context.Background(), "render template")
+	defer span.End()
+	if span.IsRecording() || span.SpanContext().IsValid() {
+		t.Error("Expected a no-op span outside a traced request")
+	}
+}
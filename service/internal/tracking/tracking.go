@@ -0,0 +1,148 @@
+// Package tracking records the delivery status of sent messages per
+// recipient, so that asynchronous outcomes such as bounces can be reported
+// after the send request has completed.
+package tracking
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/storage"
+)
+
+// bucket holds one record per message, keyed by message ID.
+const bucket = "messages"
+
+// Status is the state of a message for one recipient.
+type Status string
+
+const (
+	StatusScheduled  Status = "SCHEDULED"  // waiting to be sent at the requested time
+	StatusSent       Status = "SENT"       // accepted by the provider
+	StatusDelivered  Status = "DELIVERED"  // a delivery report confirmed it
+	StatusDeferred   Status = "DEFERRED"   // delayed or soft-bounced, may still arrive
+	StatusBounced    Status = "BOUNCED"    // permanently rejected
+	StatusComplained Status = "COMPLAINED" // reported as spam by the recipient
+	StatusSuppressed Status = "SUPPRESSED" // not sent: the recipient is on the suppression list
+	StatusCancelled  Status = "CANCELLED"  // not sent: the scheduled message was cancelled
+	StatusFailed     Status = "FAILED"     // not sent: every attempt of the scheduled message failed
+)
+
+// ErrNotFound is returned for unknown message IDs.
+var ErrNotFound = errors.New("tracking: message not found")
+
+// Message is the record of one sent message.
+type Message struct {
+	ID         string      `json:"id"`
+	Channel    string      `json:"channel"`
+	Service    string      `json:"service"` // the client that sent it
+	From       string      `json:"from"`
+	CreatedAt  time.Time   `json:"createdAt"`
+	Recipients []Recipient `json:"recipients"`
+}
+
+// Recipient is the status of a message for one recipient.
+type Recipient struct {
+	Address   string    `json:"address"`
+	Status    Status    `json:"status"`
+	Detail    string    `json:"detail,omitempty"` // e.g. the diagnostic of a bounce
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Store keeps message records in storage.
+type Store struct {
+	storage storage.Storage
+	mu      sync.Mutex // serializes read-modify-write updates
+}
+
+func NewStore(st storage.Storage) *Store {
+	return &Store{storage: st}
+}
+
+// Record saves a new message.
+func (s *Store) Record(ctx context.Context, msg *Message) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return s.storage.Put(ctx, bucket, msg.ID, data)
+}
+
+// Get returns the message with the given ID, or ErrNotFound.
+func (s *Store) Get(ctx context.Context, id string) (*Message, error) {
+	data, err := s.storage.Get(ctx, bucket, id)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var msg Message
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+// Find returns the message whose ID has the given local part, which is how
+// VERP addresses identify messages, or ErrNotFound.
+func (s *Store) Find(ctx context.Context, local string) (*Message, error) {
+	items, err := s.storage.List(ctx, bucket, local+"@")
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, ErrNotFound
+	}
+	var msg Message
+	if err := json.Unmarshal(items[0].Value, &msg); err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+// Update sets the status of the message for the recipient address, matched
+// case-insensitively. It returns ErrNotFound when the message or the
+// recipient is unknown.
+func (s *Store) Update(ctx context.Context, id, address string, status Status, detail string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	msg, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	found := false
+	for i := range msg.Recipients {
+		if r := &msg.Recipients[i]; strings.EqualFold(r.Address, address) {
+			r.Status, r.Detail, r.UpdatedAt = status, detail, time.Now().UTC()
+			found = true
+		}
+	}
+	if !found {
+		return ErrNotFound
+	}
+	return s.Record(ctx, msg)
+}
+
+// UpdateAll sets the status of the message for every recipient. It returns
+// ErrNotFound when the message is unknown.
+func (s *Store) UpdateAll(ctx context.Context, id string, status Status, detail string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	msg, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	for i := range msg.Recipients {
+		r := &msg.Recipients[i]
+		r.Status, r.Detail, r.UpdatedAt = status, detail, now
+	}
+	return s.Record(ctx, msg)
+}
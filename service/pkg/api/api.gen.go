@@ -19,6 +19,147 @@ const (
 	SignatureAuthScopes = "signatureAuth.Scopes"
 )
 
+// Defines values for BounceNotificationBounceType.
+const (
+	Hard BounceNotificationBounceType = "hard"
+	Soft BounceNotificationBounceType = "soft"
+)
+
+// Defines values for BounceNotificationType.
+const (
+	Bounce    BounceNotificationType = "bounce"
+	Complaint BounceNotificationType = "complaint"
+	Delay     BounceNotificationType = "delay"
+	Delivery  BounceNotificationType = "delivery"
+)
+
+// Defines values for DependencyCheckStatus.
+const (
+	Down DependencyCheckStatus = "down"
+	Up   DependencyCheckStatus = "up"
+)
+
+// Defines values for MessageStatusChannel.
+const (
+	Email MessageStatusChannel = "email"
+	Sms   MessageStatusChannel = "sms"
+)
+
+// Defines values for ReadinessReportStatus.
+const (
+	NotReady ReadinessReportStatus = "not_ready"
+	Ready    ReadinessReportStatus = "ready"
+)
+
+// Defines values for RecipientStatusStatus.
+const (
+	BOUNCED    RecipientStatusStatus = "BOUNCED"
+	CANCELLED  RecipientStatusStatus = "CANCELLED"
+	COMPLAINED RecipientStatusStatus = "COMPLAINED"
+	DEFERRED   RecipientStatusStatus = "DEFERRED"
+	DELIVERED  RecipientStatusStatus = "DELIVERED"
+	FAILED     RecipientStatusStatus = "FAILED"
+	SCHEDULED  RecipientStatusStatus = "SCHEDULED"
+	SENT       RecipientStatusStatus = "SENT"
+	SUPPRESSED RecipientStatusStatus = "SUPPRESSED"
+)
+
+// Defines values for SmsRequestClass.
+const (
+	Otp      SmsRequestClass = "otp"
+	Standard SmsRequestClass = "standard"
+)
+
+// Defines values for SuppressionEntryReason.
+const (
+	SuppressionEntryReasonCOMPLAINT   SuppressionEntryReason = "COMPLAINT"
+	SuppressionEntryReasonHARDBOUNCE  SuppressionEntryReason = "HARD_BOUNCE"
+	SuppressionEntryReasonMANUAL      SuppressionEntryReason = "MANUAL"
+	SuppressionEntryReasonUNSUBSCRIBE SuppressionEntryReason = "UNSUBSCRIBE"
+)
+
+// Defines values for SuppressionRequestReason.
+const (
+	SuppressionRequestReasonCOMPLAINT   SuppressionRequestReason = "COMPLAINT"
+	SuppressionRequestReasonHARDBOUNCE  SuppressionRequestReason = "HARD_BOUNCE"
+	SuppressionRequestReasonMANUAL      SuppressionRequestReason = "MANUAL"
+	SuppressionRequestReasonUNSUBSCRIBE SuppressionRequestReason = "UNSUBSCRIBE"
+)
+
+// BounceNotification A bounce or complaint reported by a provider in JSON instead of as a delivery status notification.
+type BounceNotification struct {
+	// BounceType Only hard bounces suppress the recipient.
+	BounceType *BounceNotificationBounceType `json:"bounceType,omitempty"`
+	Diagnostic *string                       `json:"diagnostic,omitempty"`
+
+	// MessageId The Message-ID of the original message, without angle brackets.
+	MessageId *string                `json:"messageId,omitempty"`
+	Recipient openapi_types.Email    `json:"recipient"`
+	Type      BounceNotificationType `json:"type"`
+}
+
+// BounceNotificationBounceType Only hard bounces suppress the recipient.
+type BounceNotificationBounceType string
+
+// BounceNotificationType defines model for BounceNotification.Type.
+type BounceNotificationType string
+
+// ConfigReloadEvent defines model for ConfigReloadEvent.
+type ConfigReloadEvent struct {
+	At time.Time `json:"at"`
+
+	// Error Why the load failed. Absent on success.
+	Error *string `json:"error,omitempty"`
+
+	// Hash Hash of the loaded config. Absent when the load failed.
+	Hash *string `json:"hash,omitempty"`
+}
+
+// ConfigStatus defines model for ConfigStatus.
+type ConfigStatus struct {
+	// Config The active config with secrets replaced by `[REDACTED]`.
+	Config map[string]interface{} `json:"config"`
+
+	// Events Most recent load attempts, oldest first.
+	Events []ConfigReloadEvent `json:"events"`
+
+	// FailedReloads Rejected reloads since startup. The previous config stays active.
+	FailedReloads int `json:"failedReloads"`
+
+	// LastError Error of the most recent failed load, if any.
+	LastError   *string    `json:"lastError,omitempty"`
+	LastErrorAt *time.Time `json:"lastErrorAt,omitempty"`
+	Path        string     `json:"path"`
+
+	// Reloads Successful reloads since startup.
+	Reloads int           `json:"reloads"`
+	Version ConfigVersion `json:"version"`
+}
+
+// ConfigVersion defines model for ConfigVersion.
+type ConfigVersion struct {
+	// Hash SHA-256 of the config file contents.
+	Hash     string    `json:"hash"`
+	LoadedAt time.Time `json:"loadedAt"`
+}
+
+// DependencyCheck defines model for DependencyCheck.
+type DependencyCheck struct {
+	CheckedAt time.Time `json:"checkedAt"`
+
+	// Error Why the probe failed. Absent when the dependency is up.
+	Error     *string `json:"error,omitempty"`
+	Kind      string  `json:"kind"`
+	LatencyMs float64 `json:"latencyMs"`
+
+	// Name Dependency name, with addresses masked like in logs.
+	Name   string                `json:"name"`
+	Status DependencyCheckStatus `json:"status"`
+}
+
+// DependencyCheckStatus defines model for DependencyCheck.Status.
+type DependencyCheckStatus string
+
 // EmailContact defines model for EmailContact.
 type EmailContact struct {
 	Address openapi_types.Email `json:"address"`
@@ -29,7 +170,15 @@ type EmailContact struct {
 type EmailRequest struct {
 	Content *EmailRequest_Content `json:"content,omitempty"`
 	From    EmailContact          `json:"from"`
-	Subject string                `json:"subject"`
+
+	// Scope Suppression scope of the email, e.g. `newsletter`. Recipients suppressed globally or in this scope are skipped. Emails to a single recipient with a scope carry one-click `List-Unsubscribe` headers when `unsubscribe` is configured.
+	Scope *string `json:"scope,omitempty"`
+
+	// SendAt Send the email at this time instead of immediately, up to a year ahead. Scheduled messages are kept in the server's storage, so they survive restarts, and can be cancelled with `DELETE /v3/messages/{messageId}` until they are sent. Suppressions are checked when the message is sent. Times in the past send immediately.
+	SendAt *time.Time `json:"sendAt,omitempty"`
+
+	// Subject Must not contain line breaks.
+	Subject string `json:"subject"`
 
 	// To A single recipient or an array of recipients.
 	To EmailRequest_To `json:"to"`
@@ -73,16 +222,112 @@ type EmailRequest_To struct {
 	union json.RawMessage
 }
 
+// EmailSuccessResponse defines model for EmailSuccessResponse.
+type EmailSuccessResponse struct {
+	Data    *map[string]interface{} `json:"data,omitempty"`
+	Message string                  `json:"message"`
+
+	// MessageId The Message-ID header of the sent message, without angle brackets.
+	MessageId string `json:"messageId"`
+
+	// Recipients The status of each recipient, `SENT` or `SUPPRESSED`, or `SCHEDULED` for scheduled emails.
+	Recipients []RecipientStatus `json:"recipients"`
+
+	// SendAt When the email will be sent. Only set for scheduled emails.
+	SendAt  *time.Time `json:"sendAt,omitempty"`
+	Success bool       `json:"success"`
+}
+
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
 	Error struct {
-		Code    string    `json:"code"`
+		// Code Stable, machine-readable error code:
+		// - `MISSING_AUTH_HEADERS`: `X-Client-Id`, `X-Timestamp` or `Authorization` is missing.
+		// - `INVALID_TIMESTAMP`: `X-Timestamp` is not an RFC 3339 date-time.
+		// - `TIMESTAMP_EXPIRED`: `X-Timestamp` is more than 5 minutes from server time.
+		// - `UNKNOWN_CLIENT`: No service is registered for `X-Client-Id`.
+		// - `INVALID_AUTH_HEADER`: `Authorization` is not `Signature <base64_signature>`, or does not carry the bounce webhook secret.
+		// - `INVALID_SIGNATURE_ENCODING`: The signature is not valid Base64.
+		// - `SIGNATURE_INVALID`: The signature does not match the canonical request.
+		// - `INVALID_BODY`: The request body is not valid JSON for the schema.
+		// - `PAYLOAD_TOO_LARGE`: The request body exceeds the configured size limit.
+		// - `INVALID_PARAMETER`: A header or parameter is missing or malformed.
+		// - `NO_RECIPIENTS`: No usable recipient could be extracted from `to`.
+		// - `FORBIDDEN`: The authenticated service may not use this endpoint.
+		// - `NOT_FOUND` / `METHOD_NOT_ALLOWED`: Unknown route or verb.
+		// - `DELIVERY_FAILED`: The upstream provider rejected or failed the delivery.
+		// - `NOT_SCHEDULED`: The message is not waiting to be sent, so it cannot be cancelled.
+		// - `INTERNAL_ERROR`: An unexpected server-side failure.
+		Code string `json:"code"`
+
+		// Details Field-level details, formatted as `<field>: <problem>` where applicable.
 		Details *[]string `json:"details,omitempty"`
 		Message string    `json:"message"`
 	} `json:"error"`
 	Success bool `json:"success"`
 }
 
+// LivenessStatus defines model for LivenessStatus.
+type LivenessStatus struct {
+	Status    string    `json:"status"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// MessageStatus defines model for MessageStatus.
+type MessageStatus struct {
+	Channel    MessageStatusChannel `json:"channel"`
+	CreatedAt  time.Time            `json:"createdAt"`
+	From       *string              `json:"from,omitempty"`
+	Id         string               `json:"id"`
+	Recipients []RecipientStatus    `json:"recipients"`
+}
+
+// MessageStatusChannel defines model for MessageStatus.Channel.
+type MessageStatusChannel string
+
+// ReadinessReport defines model for ReadinessReport.
+type ReadinessReport struct {
+	Checks []DependencyCheck     `json:"checks"`
+	Status ReadinessReportStatus `json:"status"`
+
+	// Timestamp When the dependencies were last probed.
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// ReadinessReportStatus defines model for ReadinessReport.Status.
+type ReadinessReportStatus string
+
+// RecipientStatus defines model for RecipientStatus.
+type RecipientStatus struct {
+	Address string `json:"address"`
+
+	// Detail The diagnostic of the report that set the status.
+	Detail *string `json:"detail,omitempty"`
+
+	// Status - `SCHEDULED`: Waiting to be sent at the requested time.
+	// - `SENT`: Accepted by the provider.
+	// - `DELIVERED`: A delivery report confirmed delivery.
+	// - `DEFERRED`: Delivery is delayed and may still succeed.
+	// - `BOUNCED`: The recipient's server rejected the message. Hard bounces also suppress the recipient.
+	// - `COMPLAINED`: The recipient reported the message as spam, which suppresses the recipient.
+	// - `SUPPRESSED`: Not sent, because the recipient is on the suppression list.
+	// - `CANCELLED`: Not sent, because the scheduled message was cancelled.
+	// - `FAILED`: Not sent, because every attempt to send the scheduled message failed.
+	Status    RecipientStatusStatus `json:"status"`
+	UpdatedAt time.Time             `json:"updatedAt"`
+}
+
+// RecipientStatusStatus - `SCHEDULED`: Waiting to be sent at the requested time.
+// - `SENT`: Accepted by the provider.
+// - `DELIVERED`: A delivery report confirmed delivery.
+// - `DEFERRED`: Delivery is delayed and may still succeed.
+// - `BOUNCED`: The recipient's server rejected the message. Hard bounces also suppress the recipient.
+// - `COMPLAINED`: The recipient reported the message as spam, which suppresses the recipient.
+// - `SUPPRESSED`: Not sent, because the recipient is on the suppression list.
+// - `CANCELLED`: Not sent, because the scheduled message was cancelled.
+// - `FAILED`: Not sent, because every attempt to send the scheduled message failed.
+type RecipientStatusStatus string
+
 // SmsRecipient defines model for SmsRecipient.
 type SmsRecipient struct {
 	union json.RawMessage
@@ -93,20 +338,34 @@ type SmsRecipient0 = string
 
 // SmsRecipient1 defines model for .
 type SmsRecipient1 struct {
-	// Country ISO 3166-1 alpha-2 country code.
+	// Country ISO 3166-1 alpha-2 country code. Also sets the recipient's time zone for quiet hours. For countries with several, the SMS is deferred until the window has ended in all of them, or follows the capital's when no time is outside the window in every zone.
 	Country string `json:"country"`
 	Phone   string `json:"phone"`
+
+	// TimeZone IANA time zone of the recipient for quiet hours, overriding the one of `country`.
+	TimeZone *string `json:"timeZone,omitempty"`
 }
 
 // SmsRequest defines model for SmsRequest.
 type SmsRequest struct {
-	Content    *SmsRequest_Content `json:"content,omitempty"`
-	SenderName string              `json:"senderName"`
+	// Class `otp` marks one-time passwords and other messages the recipient is waiting for, which bypass the service's quiet hours. `standard` messages that would reach a recipient during quiet hours are scheduled for the end of them.
+	Class   *SmsRequestClass    `json:"class,omitempty"`
+	Content *SmsRequest_Content `json:"content,omitempty"`
+
+	// Scope Suppression scope of the SMS. Recipients suppressed globally or in this scope are skipped.
+	Scope *string `json:"scope,omitempty"`
+
+	// SendAt Send the SMS at this time instead of immediately, up to a year ahead. Scheduled messages are kept in the server's storage, so they survive restarts, and can be cancelled with `DELETE /v3/messages/{messageId}` until they are sent. Suppressions are checked when the message is sent. Times in the past send immediately.
+	SendAt     *time.Time `json:"sendAt,omitempty"`
+	SenderName string     `json:"senderName"`
 
 	// To A single recipient or an array of recipients.
 	To SmsRequest_To `json:"to"`
 }
 
+// SmsRequestClass `otp` marks one-time passwords and other messages the recipient is waiting for, which bypass the service's quiet hours. `standard` messages that would reach a recipient during quiet hours are scheduled for the end of them.
+type SmsRequestClass string
+
 // SmsRequestContent0 defines model for .
 type SmsRequestContent0 struct {
 	Body string `json:"body"`
@@ -137,11 +396,20 @@ type SmsRequest_To struct {
 type SmsSuccessResponse struct {
 	Data    *map[string]interface{} `json:"data,omitempty"`
 	Message string                  `json:"message"`
-	Meta    *struct {
+
+	// MessageId The ID of a scheduled SMS, for its status and for cancelling it. Only set for SMS scheduled with `sendAt` or deferred by quiet hours.
+	MessageId *string `json:"messageId,omitempty"`
+	Meta      *struct {
 		Cost     *float32 `json:"cost,omitempty"`
 		Currency *string  `json:"currency,omitempty"`
 	} `json:"meta,omitempty"`
-	Success bool `json:"success"`
+
+	// Recipients The status of each recipient, `SENT` or `SUPPRESSED`, or `SCHEDULED` for scheduled SMS.
+	Recipients []RecipientStatus `json:"recipients"`
+
+	// SendAt When the SMS will be sent. Only set for scheduled SMS, and not when quiet hours defer recipients to different times.
+	SendAt  *time.Time `json:"sendAt,omitempty"`
+	Success bool       `json:"success"`
 }
 
 // SuccessResponse defines model for SuccessResponse.
@@ -151,12 +419,58 @@ type SuccessResponse struct {
 	Success bool                    `json:"success"`
 }
 
+// SuppressionEntry defines model for SuppressionEntry.
+type SuppressionEntry struct {
+	CreatedAt time.Time              `json:"createdAt"`
+	Detail    *string                `json:"detail,omitempty"`
+	Reason    SuppressionEntryReason `json:"reason"`
+
+	// Recipient The recipient, normalized (lower-case address, phone number without separators).
+	Recipient string `json:"recipient"`
+	Scope     string `json:"scope"`
+
+	// Service The service that added the entry. Absent for entries added by bounces and unsubscribe links.
+	Service *string `json:"service,omitempty"`
+}
+
+// SuppressionEntryReason defines model for SuppressionEntry.Reason.
+type SuppressionEntryReason string
+
+// SuppressionList defines model for SuppressionList.
+type SuppressionList struct {
+	Entries []SuppressionEntry `json:"entries"`
+}
+
+// SuppressionRequest defines model for SuppressionRequest.
+type SuppressionRequest struct {
+	Detail *string                   `json:"detail,omitempty"`
+	Reason *SuppressionRequestReason `json:"reason,omitempty"`
+
+	// Recipient An email address or an E.164 phone number.
+	Recipient string `json:"recipient"`
+
+	// Scope `global` blocks every send; other scopes only sends made with that `scope`.
+	Scope *string `json:"scope,omitempty"`
+}
+
+// SuppressionRequestReason defines model for SuppressionRequest.Reason.
+type SuppressionRequestReason string
+
 // ClientIdHeader defines model for ClientIdHeader.
 type ClientIdHeader = string
 
 // TimestampHeader defines model for TimestampHeader.
 type TimestampHeader = time.Time
 
+// GetAdminConfigParams defines parameters for GetAdminConfig.
+type GetAdminConfigParams struct {
+	// XClientId The unique ID assigned to your service.
+	XClientId ClientIdHeader `json:"X-Client-Id"`
+
+	// XTimestamp ISO 8601 timestamp. Requests older than 5 minutes will be rejected.
+	XTimestamp TimestampHeader `json:"X-Timestamp"`
+}
+
 // PostV3EmailParams defines parameters for PostV3Email.
 type PostV3EmailParams struct {
 	// XClientId The unique ID assigned to your service.
@@ -166,6 +480,24 @@ type PostV3EmailParams struct {
 	XTimestamp TimestampHeader `json:"X-Timestamp"`
 }
 
+// DeleteV3MessagesMessageIdParams defines parameters for DeleteV3MessagesMessageId.
+type DeleteV3MessagesMessageIdParams struct {
+	// XClientId The unique ID assigned to your service.
+	XClientId ClientIdHeader `json:"X-Client-Id"`
+
+	// XTimestamp ISO 8601 timestamp. Requests older than 5 minutes will be rejected.
+	XTimestamp TimestampHeader `json:"X-Timestamp"`
+}
+
+// GetV3MessagesMessageIdParams defines parameters for GetV3MessagesMessageId.
+type GetV3MessagesMessageIdParams struct {
+	// XClientId The unique ID assigned to your service.
+	XClientId ClientIdHeader `json:"X-Client-Id"`
+
+	// XTimestamp ISO 8601 timestamp. Requests older than 5 minutes will be rejected.
+	XTimestamp TimestampHeader `json:"X-Timestamp"`
+}
+
 // PostV3SmsParams defines parameters for PostV3Sms.
 type PostV3SmsParams struct {
 	// XClientId The unique ID assigned to your service.
@@ -175,12 +507,50 @@ type PostV3SmsParams struct {
 	XTimestamp TimestampHeader `json:"X-Timestamp"`
 }
 
+// GetV3SuppressionsParams defines parameters for GetV3Suppressions.
+type GetV3SuppressionsParams struct {
+	// Scope Only list entries in this scope. All scopes are listed when omitted.
+	Scope *string `form:"scope,omitempty" json:"scope,omitempty"`
+
+	// XClientId The unique ID assigned to your service.
+	XClientId ClientIdHeader `json:"X-Client-Id"`
+
+	// XTimestamp ISO 8601 timestamp. Requests older than 5 minutes will be rejected.
+	XTimestamp TimestampHeader `json:"X-Timestamp"`
+}
+
+// PostV3SuppressionsParams defines parameters for PostV3Suppressions.
+type PostV3SuppressionsParams struct {
+	// XClientId The unique ID assigned to your service.
+	XClientId ClientIdHeader `json:"X-Client-Id"`
+
+	// XTimestamp ISO 8601 timestamp. Requests older than 5 minutes will be rejected.
+	XTimestamp TimestampHeader `json:"X-Timestamp"`
+}
+
+// DeleteV3SuppressionsRecipientParams defines parameters for DeleteV3SuppressionsRecipient.
+type DeleteV3SuppressionsRecipientParams struct {
+	Scope *string `form:"scope,omitempty" json:"scope,omitempty"`
+
+	// XClientId The unique ID assigned to your service.
+	XClientId ClientIdHeader `json:"X-Client-Id"`
+
+	// XTimestamp ISO 8601 timestamp. Requests older than 5 minutes will be rejected.
+	XTimestamp TimestampHeader `json:"X-Timestamp"`
+}
+
 // PostV3EmailJSONRequestBody defines body for PostV3Email for application/json ContentType.
 type PostV3EmailJSONRequestBody = EmailRequest
 
 // PostV3SmsJSONRequestBody defines body for PostV3Sms for application/json ContentType.
 type PostV3SmsJSONRequestBody = SmsRequest
 
+// PostV3SuppressionsJSONRequestBody defines body for PostV3Suppressions for application/json ContentType.
+type PostV3SuppressionsJSONRequestBody = SuppressionRequest
+
+// PostWebhooksBouncesJSONRequestBody defines body for PostWebhooksBounces for application/json ContentType.
+type PostWebhooksBouncesJSONRequestBody = BounceNotification
+
 // AsEmailRequestContent0 returns the union data inside the EmailRequest_Content as a EmailRequestContent0
 func (t EmailRequest_Content) AsEmailRequestContent0() (EmailRequestContent0, error) {
 	var body EmailRequestContent0
@@ -581,39 +951,138 @@ func (t *SmsRequest_To) UnmarshalJSON(b []byte) error {
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Show the Active Config
+	// (GET /admin/config)
+	GetAdminConfig(w http.ResponseWriter, r *http.Request, params GetAdminConfigParams)
 	// Service Health Check
 	// (GET /health)
 	GetHealth(w http.ResponseWriter, r *http.Request)
+	// Liveness Check
+	// (GET /health/live)
+	GetHealthLive(w http.ResponseWriter, r *http.Request)
+	// Readiness Check
+	// (GET /health/ready)
+	GetHealthReady(w http.ResponseWriter, r *http.Request)
+	// Show the Unsubscribe Page
+	// (GET /unsubscribe/{token})
+	GetUnsubscribeToken(w http.ResponseWriter, r *http.Request, token string)
+	// Unsubscribe
+	// (POST /unsubscribe/{token})
+	PostUnsubscribeToken(w http.ResponseWriter, r *http.Request, token string)
 	// Send an Email
 	// (POST /v3/email)
 	PostV3Email(w http.ResponseWriter, r *http.Request, params PostV3EmailParams)
+	// Cancel a Scheduled Message
+	// (DELETE /v3/messages/{messageId})
+	DeleteV3MessagesMessageId(w http.ResponseWriter, r *http.Request, messageId string, params DeleteV3MessagesMessageIdParams)
+	// Get the Status of a Message
+	// (GET /v3/messages/{messageId})
+	GetV3MessagesMessageId(w http.ResponseWriter, r *http.Request, messageId string, params GetV3MessagesMessageIdParams)
 	// Send an SMS
 	// (POST /v3/sms)
 	PostV3Sms(w http.ResponseWriter, r *http.Request, params PostV3SmsParams)
+	// List Suppressed Recipients
+	// (GET /v3/suppressions)
+	GetV3Suppressions(w http.ResponseWriter, r *http.Request, params GetV3SuppressionsParams)
+	// Suppress a Recipient
+	// (POST /v3/suppressions)
+	PostV3Suppressions(w http.ResponseWriter, r *http.Request, params PostV3SuppressionsParams)
+	// Lift a Suppression
+	// (DELETE /v3/suppressions/{recipient})
+	DeleteV3SuppressionsRecipient(w http.ResponseWriter, r *http.Request, recipient string, params DeleteV3SuppressionsRecipientParams)
+	// Report a Bounce or Complaint
+	// (POST /webhooks/bounces)
+	PostWebhooksBounces(w http.ResponseWriter, r *http.Request)
 }
 
 // Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
 
 type Unimplemented struct{}
 
+// Show the Active Config
+// (GET /admin/config)
+func (_ Unimplemented) GetAdminConfig(w http.ResponseWriter, r *http.Request, params GetAdminConfigParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Service Health Check
 // (GET /health)
 func (_ Unimplemented) GetHealth(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Liveness Check
+// (GET /health/live)
+func (_ Unimplemented) GetHealthLive(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Readiness Check
+// (GET /health/ready)
+func (_ Unimplemented) GetHealthReady(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Show the Unsubscribe Page
+// (GET /unsubscribe/{token})
+func (_ Unimplemented) GetUnsubscribeToken(w http.ResponseWriter, r *http.Request, token string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Unsubscribe
+// (POST /unsubscribe/{token})
+func (_ Unimplemented) PostUnsubscribeToken(w http.ResponseWriter, r *http.Request, token string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Send an Email
 // (POST /v3/email)
 func (_ Unimplemented) PostV3Email(w http.ResponseWriter, r *http.Request, params PostV3EmailParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Cancel a Scheduled Message
+// (DELETE /v3/messages/{messageId})
+func (_ Unimplemented) DeleteV3MessagesMessageId(w http.ResponseWriter, r *http.Request, messageId string, params DeleteV3MessagesMessageIdParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the Status of a Message
+// (GET /v3/messages/{messageId})
+func (_ Unimplemented) GetV3MessagesMessageId(w http.ResponseWriter, r *http.Request, messageId string, params GetV3MessagesMessageIdParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Send an SMS
 // (POST /v3/sms)
 func (_ Unimplemented) PostV3Sms(w http.ResponseWriter, r *http.Request, params PostV3SmsParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// List Suppressed Recipients
+// (GET /v3/suppressions)
+func (_ Unimplemented) GetV3Suppressions(w http.ResponseWriter, r *http.Request, params GetV3SuppressionsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Suppress a Recipient
+// (POST /v3/suppressions)
+func (_ Unimplemented) PostV3Suppressions(w http.ResponseWriter, r *http.Request, params PostV3SuppressionsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Lift a Suppression
+// (DELETE /v3/suppressions/{recipient})
+func (_ Unimplemented) DeleteV3SuppressionsRecipient(w http.ResponseWriter, r *http.Request, recipient string, params DeleteV3SuppressionsRecipientParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Report a Bounce or Complaint
+// (POST /webhooks/bounces)
+func (_ Unimplemented) PostWebhooksBounces(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // ServerInterfaceWrapper converts contexts to parameters.
 type ServerInterfaceWrapper struct {
 	Handler            ServerInterface
@@ -623,22 +1092,8 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
-// GetHealth operation middleware
-func (siw *ServerInterfaceWrapper) GetHealth(w http.ResponseWriter, r *http.Request) {
-
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetHealth(w, r)
-	}))
-
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
-
-	handler.ServeHTTP(w, r)
-}
-
-// PostV3Email operation middleware
-func (siw *ServerInterfaceWrapper) PostV3Email(w http.ResponseWriter, r *http.Request) {
+// GetAdminConfig operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminConfig(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -649,7 +1104,7 @@ func (siw *ServerInterfaceWrapper) PostV3Email(w http.ResponseWriter, r *http.Re
 	r = r.WithContext(ctx)
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params PostV3EmailParams
+	var params GetAdminConfigParams
 
 	headers := r.Header
 
@@ -700,7 +1155,7 @@ func (siw *ServerInterfaceWrapper) PostV3Email(w http.ResponseWriter, r *http.Re
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostV3Email(w, r, params)
+		siw.Handler.GetAdminConfig(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -710,70 +1165,657 @@ func (siw *ServerInterfaceWrapper) PostV3Email(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-// PostV3Sms operation middleware
-func (siw *ServerInterfaceWrapper) PostV3Sms(w http.ResponseWriter, r *http.Request) {
-
-	var err error
-
-	ctx := r.Context()
-
-	ctx = context.WithValue(ctx, SignatureAuthScopes, []string{})
-
-	r = r.WithContext(ctx)
-
-	// Parameter object where we will unmarshal all parameters from the context
-	var params PostV3SmsParams
-
-	headers := r.Header
-
-	// ------------- Required header parameter "X-Client-Id" -------------
-	if valueList, found := headers[http.CanonicalHeaderKey("X-Client-Id")]; found {
-		var XClientId ClientIdHeader
-		n := len(valueList)
-		if n != 1 {
-			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Client-Id", Count: n})
-			return
-		}
-
-		err = runtime.BindStyledParameterWithOptions("simple", "X-Client-Id", valueList[0], &XClientId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
-		if err != nil {
-			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Client-Id", Err: err})
-			return
-		}
+// GetHealth operation middleware
+func (siw *ServerInterfaceWrapper) GetHealth(w http.ResponseWriter, r *http.Request) {
 
-		params.XClientId = XClientId
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetHealth(w, r)
+	}))
 
-	} else {
-		err := fmt.Errorf("Header parameter X-Client-Id is required, but not found")
-		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-Client-Id", Err: err})
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
-	// ------------- Required header parameter "X-Timestamp" -------------
-	if valueList, found := headers[http.CanonicalHeaderKey("X-Timestamp")]; found {
-		var XTimestamp TimestampHeader
-		n := len(valueList)
-		if n != 1 {
-			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Timestamp", Count: n})
-			return
-		}
-
-		err = runtime.BindStyledParameterWithOptions("simple", "X-Timestamp", valueList[0], &XTimestamp, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
-		if err != nil {
-			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Timestamp", Err: err})
-			return
-		}
-
-		params.XTimestamp = XTimestamp
+	handler.ServeHTTP(w, r)
+}
 
-	} else {
-		err := fmt.Errorf("Header parameter X-Timestamp is required, but not found")
-		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-Timestamp", Err: err})
-		return
-	}
+// GetHealthLive operation middleware
+func (siw *ServerInterfaceWrapper) GetHealthLive(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostV3Sms(w, r, params)
+		siw.Handler.GetHealthLive(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetHealthReady operation middleware
+func (siw *ServerInterfaceWrapper) GetHealthReady(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetHealthReady(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetUnsubscribeToken operation middleware
+func (siw *ServerInterfaceWrapper) GetUnsubscribeToken(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "token" -------------
+	var token string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "token", chi.URLParam(r, "token"), &token, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "token", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUnsubscribeToken(w, r, token)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostUnsubscribeToken operation middleware
+func (siw *ServerInterfaceWrapper) PostUnsubscribeToken(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "token" -------------
+	var token string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "token", chi.URLParam(r, "token"), &token, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "token", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostUnsubscribeToken(w, r, token)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostV3Email operation middleware
+func (siw *ServerInterfaceWrapper) PostV3Email(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, SignatureAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostV3EmailParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "X-Client-Id" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Client-Id")]; found {
+		var XClientId ClientIdHeader
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Client-Id", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Client-Id", valueList[0], &XClientId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Client-Id", Err: err})
+			return
+		}
+
+		params.XClientId = XClientId
+
+	} else {
+		err := fmt.Errorf("Header parameter X-Client-Id is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-Client-Id", Err: err})
+		return
+	}
+
+	// ------------- Required header parameter "X-Timestamp" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Timestamp")]; found {
+		var XTimestamp TimestampHeader
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Timestamp", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Timestamp", valueList[0], &XTimestamp, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Timestamp", Err: err})
+			return
+		}
+
+		params.XTimestamp = XTimestamp
+
+	} else {
+		err := fmt.Errorf("Header parameter X-Timestamp is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-Timestamp", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostV3Email(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteV3MessagesMessageId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteV3MessagesMessageId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "messageId" -------------
+	var messageId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "messageId", chi.URLParam(r, "messageId"), &messageId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "messageId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, SignatureAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteV3MessagesMessageIdParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "X-Client-Id" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Client-Id")]; found {
+		var XClientId ClientIdHeader
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Client-Id", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Client-Id", valueList[0], &XClientId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Client-Id", Err: err})
+			return
+		}
+
+		params.XClientId = XClientId
+
+	} else {
+		err := fmt.Errorf("Header parameter X-Client-Id is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-Client-Id", Err: err})
+		return
+	}
+
+	// ------------- Required header parameter "X-Timestamp" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Timestamp")]; found {
+		var XTimestamp TimestampHeader
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Timestamp", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Timestamp", valueList[0], &XTimestamp, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Timestamp", Err: err})
+			return
+		}
+
+		params.XTimestamp = XTimestamp
+
+	} else {
+		err := fmt.Errorf("Header parameter X-Timestamp is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-Timestamp", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteV3MessagesMessageId(w, r, messageId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetV3MessagesMessageId operation middleware
+func (siw *ServerInterfaceWrapper) GetV3MessagesMessageId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "messageId" -------------
+	var messageId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "messageId", chi.URLParam(r, "messageId"), &messageId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "messageId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, SignatureAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetV3MessagesMessageIdParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "X-Client-Id" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Client-Id")]; found {
+		var XClientId ClientIdHeader
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Client-Id", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Client-Id", valueList[0], &XClientId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Client-Id", Err: err})
+			return
+		}
+
+		params.XClientId = XClientId
+
+	} else {
+		err := fmt.Errorf("Header parameter X-Client-Id is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-Client-Id", Err: err})
+		return
+	}
+
+	// ------------- Required header parameter "X-Timestamp" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Timestamp")]; found {
+		var XTimestamp TimestampHeader
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Timestamp", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Timestamp", valueList[0], &XTimestamp, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Timestamp", Err: err})
+			return
+		}
+
+		params.XTimestamp = XTimestamp
+
+	} else {
+		err := fmt.Errorf("Header parameter X-Timestamp is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-Timestamp", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetV3MessagesMessageId(w, r, messageId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostV3Sms operation middleware
+func (siw *ServerInterfaceWrapper) PostV3Sms(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, SignatureAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostV3SmsParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "X-Client-Id" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Client-Id")]; found {
+		var XClientId ClientIdHeader
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Client-Id", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Client-Id", valueList[0], &XClientId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Client-Id", Err: err})
+			return
+		}
+
+		params.XClientId = XClientId
+
+	} else {
+		err := fmt.Errorf("Header parameter X-Client-Id is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-Client-Id", Err: err})
+		return
+	}
+
+	// ------------- Required header parameter "X-Timestamp" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Timestamp")]; found {
+		var XTimestamp TimestampHeader
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Timestamp", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Timestamp", valueList[0], &XTimestamp, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Timestamp", Err: err})
+			return
+		}
+
+		params.XTimestamp = XTimestamp
+
+	} else {
+		err := fmt.Errorf("Header parameter X-Timestamp is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-Timestamp", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostV3Sms(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetV3Suppressions operation middleware
+func (siw *ServerInterfaceWrapper) GetV3Suppressions(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, SignatureAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetV3SuppressionsParams
+
+	// ------------- Optional query parameter "scope" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "scope", r.URL.Query(), &params.Scope)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "scope", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Required header parameter "X-Client-Id" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Client-Id")]; found {
+		var XClientId ClientIdHeader
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Client-Id", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Client-Id", valueList[0], &XClientId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Client-Id", Err: err})
+			return
+		}
+
+		params.XClientId = XClientId
+
+	} else {
+		err := fmt.Errorf("Header parameter X-Client-Id is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-Client-Id", Err: err})
+		return
+	}
+
+	// ------------- Required header parameter "X-Timestamp" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Timestamp")]; found {
+		var XTimestamp TimestampHeader
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Timestamp", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Timestamp", valueList[0], &XTimestamp, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Timestamp", Err: err})
+			return
+		}
+
+		params.XTimestamp = XTimestamp
+
+	} else {
+		err := fmt.Errorf("Header parameter X-Timestamp is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-Timestamp", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetV3Suppressions(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostV3Suppressions operation middleware
+func (siw *ServerInterfaceWrapper) PostV3Suppressions(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, SignatureAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostV3SuppressionsParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "X-Client-Id" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Client-Id")]; found {
+		var XClientId ClientIdHeader
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Client-Id", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Client-Id", valueList[0], &XClientId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Client-Id", Err: err})
+			return
+		}
+
+		params.XClientId = XClientId
+
+	} else {
+		err := fmt.Errorf("Header parameter X-Client-Id is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-Client-Id", Err: err})
+		return
+	}
+
+	// ------------- Required header parameter "X-Timestamp" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Timestamp")]; found {
+		var XTimestamp TimestampHeader
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Timestamp", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Timestamp", valueList[0], &XTimestamp, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Timestamp", Err: err})
+			return
+		}
+
+		params.XTimestamp = XTimestamp
+
+	} else {
+		err := fmt.Errorf("Header parameter X-Timestamp is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-Timestamp", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostV3Suppressions(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteV3SuppressionsRecipient operation middleware
+func (siw *ServerInterfaceWrapper) DeleteV3SuppressionsRecipient(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "recipient" -------------
+	var recipient string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "recipient", chi.URLParam(r, "recipient"), &recipient, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "recipient", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, SignatureAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteV3SuppressionsRecipientParams
+
+	// ------------- Optional query parameter "scope" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "scope", r.URL.Query(), &params.Scope)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "scope", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Required header parameter "X-Client-Id" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Client-Id")]; found {
+		var XClientId ClientIdHeader
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Client-Id", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Client-Id", valueList[0], &XClientId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Client-Id", Err: err})
+			return
+		}
+
+		params.XClientId = XClientId
+
+	} else {
+		err := fmt.Errorf("Header parameter X-Client-Id is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-Client-Id", Err: err})
+		return
+	}
+
+	// ------------- Required header parameter "X-Timestamp" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Timestamp")]; found {
+		var XTimestamp TimestampHeader
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Timestamp", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Timestamp", valueList[0], &XTimestamp, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Timestamp", Err: err})
+			return
+		}
+
+		params.XTimestamp = XTimestamp
+
+	} else {
+		err := fmt.Errorf("Header parameter X-Timestamp is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-Timestamp", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteV3SuppressionsRecipient(w, r, recipient, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostWebhooksBounces operation middleware
+func (siw *ServerInterfaceWrapper) PostWebhooksBounces(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostWebhooksBounces(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -896,15 +1938,48 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/config", wrapper.GetAdminConfig)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/health", wrapper.GetHealth)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/health/live", wrapper.GetHealthLive)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/health/ready", wrapper.GetHealthReady)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/unsubscribe/{token}", wrapper.GetUnsubscribeToken)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/unsubscribe/{token}", wrapper.PostUnsubscribeToken)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/v3/email", wrapper.PostV3Email)
 	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v3/messages/{messageId}", wrapper.DeleteV3MessagesMessageId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v3/messages/{messageId}", wrapper.GetV3MessagesMessageId)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/v3/sms", wrapper.PostV3Sms)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v3/suppressions", wrapper.GetV3Suppressions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v3/suppressions", wrapper.PostV3Suppressions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v3/suppressions/{recipient}", wrapper.DeleteV3SuppressionsRecipient)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/webhooks/bounces", wrapper.PostWebhooksBounces)
+	})
 
 	return r
 }
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/config"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/delivery"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/health"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/message"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/storage"
+)
+
+// Config types, re-exported so that embedders can build a config in code.
+type (
+	Config             = config.Config
+	ServerConfig       = config.ServerConfig
+	TLSConfig          = config.TLSConfig
+	LoggingConfig      = config.LoggingConfig
+	TracingConfig      = config.TracingConfig
+	HealthConfig       = config.HealthConfig
+	ServiceConfig      = config.ServiceConfig
+	QuietHoursConfig   = config.QuietHoursConfig
+	EmailAccountConfig = config.EmailAccountConfig
+	SMTPConfig         = config.SMTPConfig
+	SMTPPoolConfig     = config.SMTPPoolConfig
+	SMTPOAuth2Config   = config.SMTPOAuth2Config
+	DKIMConfig         = config.DKIMConfig
+	FortySixElksConfig = config.FortySixElksConfig
+	BouncesConfig      = config.BouncesConfig
+	POP3Config         = config.POP3Config
+	UnsubscribeConfig  = config.UnsubscribeConfig
+	StorageConfig      = config.StorageConfig
+	Secret             = config.Secret
+)
+
+// EmailProvider delivers email. The default sends through the SMTP accounts
+// in the config.
+type EmailProvider = delivery.EmailSender
+
+// Email is the message handed to an EmailProvider.
+type Email = message.Email
+
+// SmsProvider delivers SMS. The default sends through 46elks.
+type SmsProvider = delivery.SmsSender
+
+// HealthSource is implemented by providers that can be probed by
+// /health/ready. Injected providers implementing it are probed too.
+type HealthSource = health.Source
+
+// HealthProbe checks one dependency of a HealthSource.
+type HealthProbe = health.Probe
+
+// Storage persists service state. The default is selected by the storage
+// config: a bolt file, or memory.
+type Storage = storage.Storage
+
+// ErrNotFound is returned by Storage.Get for missing keys.
+var ErrNotFound = storage.ErrNotFound
+
+// ParseConfig decodes and validates a YAML config document, resolving
+// ${VAR} and file: references like the config file does.
+func ParseConfig(data []byte) (*Config, error) {
+	return config.Parse(data)
+}
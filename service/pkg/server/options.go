@@ -0,0 +1,57 @@
+package server
+
+import (
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Option customizes a Server created by New.
+type Option func(*Server)
+
+// WithConfigFile backs the server with the config file at path. When New is
+// called with a nil config the file is loaded, and created with defaults if it
+// is missing. Run reloads it whenever it changes.
+func WithConfigFile(path string) Option {
+	return func(s *Server) {
+		s.configFile = path
+	}
+}
+
+// WithEmailProvider replaces the SMTP provider, e.g. with a fake in tests.
+func WithEmailProvider(p EmailProvider) Option {
+	return func(s *Server) {
+		s.email = p
+	}
+}
+
+// WithSmsProvider replaces the 46elks provider.
+func WithSmsProvider(p SmsProvider) Option {
+	return func(s *Server) {
+		s.sms = p
+	}
+}
+
+// WithStorage replaces the storage selected by the storage config. The caller
+// remains responsible for closing it.
+func WithStorage(st Storage) Option {
+	return func(s *Server) {
+		s.storage = st
+	}
+}
+
+// WithLogger replaces the logger built from the logging config. Records are
+// still masked and tagged with request IDs; the level is left to l.
+func WithLogger(l *slog.Logger) Option {
+	return func(s *Server) {
+		s.logger = l
+	}
+}
+
+// WithTracerProvider traces requests with tp instead of a provider built from
+// the tracing config. The caller remains responsible for shutting it down.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(s *Server) {
+		s.tracerProvider = tp
+	}
+}
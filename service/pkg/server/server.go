@@ -0,0 +1,293 @@
+// Package server assembles the Message Delivery Service: request
+// authentication, the v3 API handlers and the delivery providers. It lets
+// the service run standalone, be mounted inside another binary, or be
+// started in integration tests.
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/apierror"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/auth"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/bounce"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/certs"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/config"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/delivery"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/handlers"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/health"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/logging"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/metrics"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/schedule"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/storage"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/suppression"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/tracing"
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/tracking"
+	"github.com/Low-Stack-Technologies/message-delivery-service/pkg/api"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ShutdownTimeout bounds how long Run waits for in-flight requests.
+const ShutdownTimeout = 5 * time.Second
+
+// Server is one instance of the service. Several servers with different
+// configs can live in the same process.
+type Server struct {
+	configFile string
+	store      *config.Store
+
+	email   EmailProvider
+	sms     SmsProvider
+	smtp    *delivery.EmailProvider // the default email provider, if used
+	storage Storage
+	db      *storage.Bolt // the default storage, if opened from the config
+	bounces *bounce.Poller
+	queue   *schedule.Queue
+	logger  *slog.Logger
+	metrics *metrics.Metrics
+
+	tracerProvider trace.TracerProvider
+	stopTracing    func(context.Context) error
+
+	handler    http.Handler
+	dispatcher schedule.Dispatcher
+}
+
+// New builds a server for cfg. cfg may be nil when WithConfigFile is given.
+// Configs built in code are validated like a config file would be.
+func New(cfg *Config, opts ...Option) (*Server, error) {
+	s := &Server{}
+	for _, opt := range opts {
+		opt(s)
+	}
+	// 1. Config
+	s.store = config.NewStore(s.configFile)
+	switch {
+	case cfg != nil:
+		if err := cfg.Prepare(); err != nil {
+			return nil, err
+		}
+		s.store.Set(cfg)
+	case s.configFile != "":
+		if _, err := s.store.Load(); err != nil {
+			return nil, fmt.Errorf("failed to load config: %w", err)
+		}
+	default:
+		return nil, errors.New("server: a config or WithConfigFile is required")
+	}
+
+	// 2. Logger (the level follows config reloads)
+	if s.logger == nil {
+		logger, level := logging.New(os.Stderr, s.store.Get())
+		s.store.Subscribe(func(cfg *config.Config) {
+			level.Set(logging.Level(cfg))
+		})
+		s.logger = logger
+	} else {
+		s.logger = logging.Wrap(s.logger)
+	}
+	s.store.SetLogger(s.logger)
+
+	// 3. Tracing
+	s.stopTracing = func(context.Context) error { return nil }
+	if s.tracerProvider == nil {
+		tp, stop, err := tracing.NewProvider(context.Background(), s.store.Get().Tracing)
+		if err != nil {
+			return nil, err
+		}
+		s.tracerProvider, s.stopTracing = tp, stop
+	}
+
+	// 4. Providers (the defaults follow config reloads). Providers that list
+	// probes are checked by /health/ready.
+	s.metrics = metrics.New()
+	emailProvider, smsProvider := "custom", "custom"
+	if s.email == nil {
+		s.smtp = delivery.NewEmailProvider(s.store, s.logger)
+		s.email, emailProvider = s.smtp, "smtp"
+	}
+	if s.sms == nil {
+		s.sms, smsProvider = delivery.NewSmsProvider(s.store, s.logger), "46elks"
+	}
+	var sources []health.Source
+	for _, p := range []interface{}{s.email, s.sms} {
+		if src, ok := p.(health.Source); ok {
+			sources = append(sources, src)
+		}
+	}
+	// 5. Storage (fixed at startup)
+	if s.storage == nil {
+		switch st := s.store.Get().Storage; st.Driver {
+		case config.StorageMemory:
+			s.storage = storage.NewMemory()
+		default:
+			db, err := storage.OpenBolt(st.Path)
+			if err != nil {
+				s.stopTracing(context.Background())
+				return nil, fmt.Errorf("failed to open storage: %w", err)
+			}
+			s.storage, s.db = db, db
+		}
+	}
+
+	// 6. Bounces (reported by webhook or read from the bounce mailbox) and
+	// scheduled messages (sent by Run)
+	messages, suppressions := tracking.NewStore(s.storage), suppression.NewList(s.storage)
+	processor := bounce.NewProcessor(messages, suppressions, s.logger)
+	s.bounces = bounce.NewPoller(s.store, processor, s.logger)
+	s.queue = schedule.NewQueue(s.storage, s.logger)
+	s.queue.ReportDepth(func(pending int) { s.metrics.SetQueueDepth(metrics.QueueScheduled, pending) })
+
+	h := handlers.NewHandler(s.store,
+		s.metrics.InstrumentEmail(s.email, emailProvider),
+		s.metrics.InstrumentSms(s.sms, smsProvider),
+		health.NewChecker(s.store, s.logger, sources...),
+		messages, suppressions, s.queue, processor,
+		s.logger)
+	s.dispatcher = h.Scheduled()
+
+	// 7. Router
+	r := chi.NewRouter()
+	r.Use(middleware.RequestID)
+	r.Use(tracing.Middleware(s.tracerProvider))
+	r.Use(logging.Middleware(s.logger))
+	r.Use(s.metrics.Middleware)
+	r.Use(middleware.Recoverer)
+	r.NotFound(apierror.NotFound)
+	r.MethodNotAllowed(apierror.MethodNotAllowed)
+
+	// Public routes
+	r.Get("/health", h.GetHealth)
+	r.Get("/health/live", h.GetHealthLive)
+	r.Get("/health/ready", h.GetHealthReady)
+	r.Method(http.MethodGet, "/metrics", s.metrics.Handler())
+
+	// Protected routes (webhooks check their own secret)
+	r.Group(func(r chi.Router) {
+		r.Use(auth.NewMiddleware(s.store, s.logger, s.metrics))
+		api.HandlerWithOptions(h, api.ChiServerOptions{
+			BaseRouter:       r,
+			ErrorHandlerFunc: apierror.ParamErrorHandler,
+		})
+	})
+	s.handler = r
+
+	return s, nil
+}
+
+// Handler returns the HTTP handler serving the API, for mounting in another
+// server or in httptest.
+func (s *Server) Handler() http.Handler {
+	return s.handler
+}
+
+// Config returns the active config.
+func (s *Server) Config() *Config {
+	return s.store.Get()
+}
+
+// MetricsRegistry returns the Prometheus registry served on /metrics, for
+// registering additional collectors.
+func (s *Server) MetricsRegistry() *prometheus.Registry {
+	return s.metrics.Registry()
+}
+
+// Storage returns the storage backing the server.
+func (s *Server) Storage() Storage {
+	return s.storage
+}
+
+// Run listens on the configured address, over HTTPS when TLS is configured,
+// and serves until ctx is cancelled. The config file, if any, and the TLS
+// certificates are reloaded when they change, scheduled messages are sent
+// when due, and the bounce mailbox is polled when configured.
+//
+// In-flight requests get ShutdownTimeout to complete. Then, and also when
+// Run fails, pooled SMTP connections and the storage opened from the config
+// are closed and buffered spans are flushed.
+func (s *Server) Run(ctx context.Context) error {
+	cfg := s.store.Get()
+	addr := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port)
+	srv := &http.Server{
+		Addr:     addr,
+		Handler:  s.handler,
+		ErrorLog: slog.NewLogLogger(s.logger.Handler(), slog.LevelError),
+	}
+
+	watchCtx, stopWatch := context.WithCancel(ctx)
+	var workers sync.WaitGroup
+	defer func() {
+		stopWatch()
+		workers.Wait()
+		s.close()
+	}()
+
+	if s.configFile != "" {
+		if err := s.store.Watch(watchCtx); err != nil {
+			s.logger.Warn("Failed to start config watcher", "error", err)
+		}
+	}
+
+	if cfg.Server.TLS.Enabled() {
+		reloader, err := certs.NewReloader(cfg.Server.TLS, s.logger)
+		if err != nil {
+			return fmt.Errorf("failed to load TLS certificates: %w", err)
+		}
+		srv.TLSConfig = reloader.TLSConfig()
+		go reloader.Watch(watchCtx, s.store, certs.DefaultCheckInterval)
+	}
+	workers.Go(func() { s.bounces.Run(watchCtx) })
+	workers.Go(func() { s.queue.Run(watchCtx, s.dispatcher) })
+
+	errCh := make(chan error, 1)
+	go func() {
+		if srv.TLSConfig != nil {
+			s.logger.Info("Starting HTTPS server", "addr", addr)
+			errCh <- srv.ListenAndServeTLS("", "")
+		} else {
+			s.logger.Info("Starting server", "addr", addr)
+			errCh <- srv.ListenAndServe()
+		}
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	s.logger.Info("Shutting down server...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("server forced to shutdown: %w", err)
+	}
+	return nil
+}
+
+// close releases what New set up once Run has stopped serving and its
+// background work has ended.
+func (s *Server) close() {
+	if s.smtp != nil {
+		s.smtp.Close()
+	}
+	if s.db != nil {
+		if err := s.db.Close(); err != nil {
+			s.logger.Warn("Failed to close storage", "error", err)
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), ShutdownTimeout)
+	defer cancel()
+	if err := s.stopTracing(ctx); err != nil {
+		s.logger.Warn("Failed to flush traces", "error", err)
+	}
+}
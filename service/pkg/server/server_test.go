@@ -0,0 +1,825 @@
+package server_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/pkg/server"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+var quiet = slog.New(slog.DiscardHandler)
+
+type fakeEmail struct {
+	mu     sync.Mutex
+	sent   []string
+	emails []*server.Email
+}
+
+func (f *fakeEmail) Send(ctx context.Context, email *server.Email) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = append(f.sent, email.From.Address+" -> "+strings.Join(email.Recipients(), ",")+": "+email.Subject)
+	f.emails = append(f.emails, email)
+	return "1@example.com", nil
+}
+
+type fakeSms struct {
+	mu   sync.Mutex
+	sent []string
+	fail string // a number that cannot be sent to
+}
+
+func (f *fakeSms) Send(ctx context.Context, from string, to []string, body string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if slices.Contains(to, f.fail) {
+		return errors.New("provider unavailable")
+	}
+	f.sent = append(f.sent, from+" -> "+strings.Join(to, ",")+": "+body)
+	return nil
+}
+
+// probedEmail is an email provider that reports a dependency for readiness.
+type probedEmail struct {
+	fakeEmail
+	err error
+}
+
+func (p *probedEmail) Probes() []server.HealthProbe {
+	return []server.HealthProbe{{
+		Name:  "relay",
+		Kind:  "smtp",
+		Check: func(ctx context.Context) error { return p.err },
+	}}
+}
+
+func newConfig(t *testing.T) (*server.Config, ed25519.PrivateKey) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	return &server.Config{
+		Services: []server.ServiceConfig{
+			{ID: "monolith", PublicKey: base64.StdEncoding.EncodeToString(pub)},
+		},
+		Storage: server.StorageConfig{Driver: "memory"},
+	}, priv
+}
+
+func sign(priv ed25519.PrivateKey, req *http.Request, body string) {
+	signAs("monolith", priv, req, body)
+}
+
+func signAs(clientID string, priv ed25519.PrivateKey, req *http.Request, body string) {
+	timestamp := time.Now().Format(time.RFC3339)
+	bodyHash := sha256.Sum256([]byte(body))
+	canonical := req.Method + "\n" + req.URL.Path + "\n" + timestamp + "\n" + hex.EncodeToString(bodyHash[:])
+	req.Header.Set("X-Client-Id", clientID)
+	req.Header.Set("X-Timestamp", timestamp)
+	req.Header.Set("Authorization", "Signature "+base64.StdEncoding.EncodeToString(ed25519.Sign(priv, []byte(canonical))))
+}
+
+func TestServer_HandlerWithInjectedProvider(t *testing.T) {
+	t.Parallel()
+
+	cfg, priv := newConfig(t)
+	email := &fakeEmail{}
+	srv, err := server.New(cfg, server.WithEmailProvider(email), server.WithLogger(quiet))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	body := `{"from":{"address":"app@example.com"},"to":"user@example.com","subject":"Hello","content":{"body":"Hi"}}`
+	req := httptest.NewRequest(http.MethodPost, "/v3/email", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	sign(priv, req, body)
+
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("Expected 202, got %d: %s", rec.Code, rec.Body)
+	}
+	if len(email.sent) != 1 || email.sent[0] != "app@example.com -> user@example.com: Hello" {
+		t.Errorf("Unexpected deliveries: %v", email.sent)
+	}
+	if !strings.Contains(rec.Body.String(), `"messageId":"1@example.com"`) {
+		t.Errorf("Expected the Message-ID in the response, got %s", rec.Body)
+	}
+
+	rec = httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	for _, line := range []string{
+		`mds_http_requests_total{client_id="monolith",route="/v3/email",status="202"} 1`,
+		`mds_deliveries_total{channel="email",outcome="success",provider="custom"} 1`,
+	} {
+		if !strings.Contains(rec.Body.String(), line) {
+			t.Errorf("Metrics are missing %q", line)
+		}
+	}
+}
+
+func TestServer_RejectsHeaderInjection(t *testing.T) {
+	t.Parallel()
+
+	cfg, priv := newConfig(t)
+	email := &fakeEmail{}
+	srv, err := server.New(cfg, server.WithEmailProvider(email), server.WithLogger(quiet))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	body := `{"from":{"address":"app@example.com","name":"App\r\nBcc: x@example.com"},"to":"user@example.com","subject":"Hi\nBcc: y@example.com"}`
+	req := httptest.NewRequest(http.MethodPost, "/v3/email", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	sign(priv, req, body)
+
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("Expected 400, got %d: %s", rec.Code, rec.Body)
+	}
+	for _, detail := range []string{"from.name: must not contain line breaks", "subject: must not contain line breaks"} {
+		if !strings.Contains(rec.Body.String(), detail) {
+			t.Errorf("Expected detail %q, got %s", detail, rec.Body)
+		}
+	}
+	if len(email.sent) != 0 {
+		t.Errorf("Expected nothing to be sent, got %v", email.sent)
+	}
+}
+
+func TestServer_BounceWebhook(t *testing.T) {
+	t.Parallel()
+
+	cfg, priv := newConfig(t)
+	cfg.Bounces.WebhookSecret = "hook-secret"
+	srv, err := server.New(cfg, server.WithEmailProvider(&fakeEmail{}), server.WithLogger(quiet))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	serve := func(req *http.Request) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		srv.Handler().ServeHTTP(rec, req)
+		return rec
+	}
+
+	body := `{"from":{"address":"app@example.com"},"to":["gone@example.com","user@example.com"],"subject":"Hello"}`
+	req := httptest.NewRequest(http.MethodPost, "/v3/email", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	sign(priv, req, body)
+	if rec := serve(req); rec.Code != http.StatusAccepted {
+		t.Fatalf("Expected 202, got %d: %s", rec.Code, rec.Body)
+	}
+
+	// 1. Report
+	bounce := `{"type":"bounce","recipient":"gone@example.com","messageId":"1@example.com","diagnostic":"550 5.1.1 User unknown"}`
+	req = httptest.NewRequest(http.MethodPost, "/webhooks/bounces", strings.NewReader(bounce))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer wrong")
+	if rec := serve(req); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("Expected 401 for a wrong secret, got %d: %s", rec.Code, rec.Body)
+	}
+	req = httptest.NewRequest(http.MethodPost, "/webhooks/bounces", strings.NewReader(bounce))
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth("provider", "hook-secret")
+	if rec := serve(req); rec.Code != http.StatusAccepted {
+		t.Fatalf("Expected 202, got %d: %s", rec.Code, rec.Body)
+	}
+
+	// 2. Status
+	req = httptest.NewRequest(http.MethodGet, "/v3/messages/1@example.com", nil)
+	sign(priv, req, "")
+	rec := serve(req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	var status struct {
+		Recipients []struct{ Address, Status, Detail string }
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &status); err != nil {
+		t.Fatalf("Failed to decode status: %v", err)
+	}
+	if len(status.Recipients) != 2 ||
+		status.Recipients[0].Status != "BOUNCED" || status.Recipients[0].Detail != "550 5.1.1 User unknown" ||
+		status.Recipients[1].Status != "SENT" {
+		t.Errorf("Unexpected status: %s", rec.Body)
+	}
+}
+
+func TestServer_Suppressions(t *testing.T) {
+	t.Parallel()
+
+	cfg, priv := newConfig(t)
+	cfg.Services[0].Admin = true
+	pub, otherPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	cfg.Services = append(cfg.Services, server.ServiceConfig{ID: "billing", PublicKey: base64.StdEncoding.EncodeToString(pub)})
+	cfg.Unsubscribe = server.UnsubscribeConfig{BaseURL: "https://mds.example.com/", Secret: "0123456789abcdef"}
+	email := &fakeEmail{}
+	srv, err := server.New(cfg, server.WithEmailProvider(email), server.WithLogger(quiet))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	callAs := func(clientID string, priv ed25519.PrivateKey, method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		signAs(clientID, priv, req, body)
+		rec := httptest.NewRecorder()
+		srv.Handler().ServeHTTP(rec, req)
+		return rec
+	}
+	call := func(method, path, body string) *httptest.ResponseRecorder {
+		return callAs("monolith", priv, method, path, body)
+	}
+	callOther := func(method, path, body string) *httptest.ResponseRecorder {
+		return callAs("billing", otherPriv, method, path, body)
+	}
+	send := func(to, scope string) string {
+		body := `{"from":{"address":"app@example.com"},"to":` + to + `,"subject":"News"` + scope + `}`
+		rec := call(http.MethodPost, "/v3/email", body)
+		if rec.Code != http.StatusAccepted {
+			t.Fatalf("Expected 202, got %d: %s", rec.Code, rec.Body)
+		}
+		var resp struct {
+			Recipients []struct{ Address, Status string }
+		}
+		json.Unmarshal(rec.Body.Bytes(), &resp)
+		var statuses []string
+		for _, r := range resp.Recipients {
+			statuses = append(statuses, r.Address+"="+r.Status)
+		}
+		return strings.Join(statuses, ",")
+	}
+
+	// 1. Suppressed recipients are skipped
+	if rec := call(http.MethodPost, "/v3/suppressions", `{"recipient":"Gone@Example.com"}`); rec.Code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d: %s", rec.Code, rec.Body)
+	}
+	if got := send(`["gone@example.com","user@example.com"]`, ""); got != "gone@example.com=SUPPRESSED,user@example.com=SENT" {
+		t.Errorf("Unexpected statuses: %s", got)
+	}
+	if got := send(`"gone@example.com"`, ""); got != "gone@example.com=SUPPRESSED" {
+		t.Errorf("Unexpected statuses: %s", got)
+	}
+	if len(email.sent) != 1 || email.sent[0] != "app@example.com -> user@example.com: News" {
+		t.Errorf("Unexpected deliveries: %v", email.sent)
+	}
+
+	// 2. One-click unsubscribe from a scope
+	send(`"user@example.com"`, `,"scope":"newsletter"`)
+	link := email.emails[len(email.emails)-1].ListUnsubscribe
+	path, ok := strings.CutPrefix(link, "https://mds.example.com/unsubscribe/")
+	if !ok {
+		t.Fatalf("Expected an unsubscribe link, got %q", link)
+	}
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/unsubscribe/"+path, strings.NewReader("List-Unsubscribe=One-Click")))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 from the unsubscribe link, got %d: %s", rec.Code, rec.Body)
+	}
+	rec = httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/unsubscribe/x"+path, nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a forged link, got %d", rec.Code)
+	}
+	if got := send(`"user@example.com"`, `,"scope":"newsletter"`); got != "user@example.com=SUPPRESSED" {
+		t.Errorf("Expected the scope to be suppressed, got %s", got)
+	}
+	if got := send(`"user@example.com"`, ""); got != "user@example.com=SENT" {
+		t.Errorf("Expected unscoped sends to continue, got %s", got)
+	}
+
+	// 3. List
+	rec = call(http.MethodGet, "/v3/suppressions", "")
+	if !strings.Contains(rec.Body.String(), `"recipient":"gone@example.com","scope":"global"`) ||
+		!strings.Contains(rec.Body.String(), `"reason":"UNSUBSCRIBE","recipient":"user@example.com","scope":"newsletter"`) {
+		t.Errorf("Unexpected list: %s", rec.Body)
+	}
+	if rec := call(http.MethodGet, "/v3/suppressions?scope=news%20letter", ""); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 when listing an invalid scope, got %d: %s", rec.Code, rec.Body)
+	}
+	if rec := call(http.MethodDelete, "/v3/suppressions/gone@example.com?scope=news%20letter", ""); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 when lifting an invalid scope, got %d: %s", rec.Code, rec.Body)
+	}
+
+	// 4. Other services see global entries and their own, and may only
+	// lift their own
+	if rec := callOther(http.MethodPost, "/v3/suppressions", `{"recipient":"payer@example.com","scope":"billing"}`); rec.Code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d: %s", rec.Code, rec.Body)
+	}
+	rec = callOther(http.MethodGet, "/v3/suppressions", "")
+	if !strings.Contains(rec.Body.String(), `"recipient":"gone@example.com","scope":"global"`) ||
+		!strings.Contains(rec.Body.String(), `"recipient":"payer@example.com","scope":"billing","service":"billing"`) ||
+		strings.Contains(rec.Body.String(), `"scope":"newsletter"`) {
+		t.Errorf("Unexpected list for another service: %s", rec.Body)
+	}
+	if rec := callOther(http.MethodDelete, "/v3/suppressions/gone@example.com", ""); rec.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 when lifting a global suppression, got %d: %s", rec.Code, rec.Body)
+	}
+	if rec := callOther(http.MethodDelete, "/v3/suppressions/user@example.com?scope=newsletter", ""); rec.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 when lifting another service's suppression, got %d: %s", rec.Code, rec.Body)
+	}
+	if rec := callOther(http.MethodDelete, "/v3/suppressions/payer@example.com?scope=billing", ""); rec.Code != http.StatusOK {
+		t.Errorf("Expected 200 when lifting its own suppression, got %d: %s", rec.Code, rec.Body)
+	}
+
+	// 5. Admins lift any suppression
+	if rec := call(http.MethodDelete, "/v3/suppressions/gone@example.com", ""); rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	if got := send(`"gone@example.com"`, ""); got != "gone@example.com=SENT" {
+		t.Errorf("Expected the lifted suppression to allow sends, got %s", got)
+	}
+}
+
+func TestServer_ScheduledMessages(t *testing.T) {
+	t.Parallel()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	port := l.Addr().(*net.TCPAddr).Port
+	l.Close()
+
+	cfg, priv := newConfig(t)
+	cfg.Server.Host = "127.0.0.1"
+	cfg.Server.Port = port
+	email := &fakeEmail{}
+	srv, err := server.New(cfg, server.WithEmailProvider(email), server.WithLogger(quiet))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	call := func(srv *server.Server, method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		sign(priv, req, body)
+		rec := httptest.NewRecorder()
+		srv.Handler().ServeHTTP(rec, req)
+		return rec
+	}
+	schedule := func(sendAt time.Time) string {
+		body := `{"from":{"address":"app@example.com"},"to":"user@example.com","subject":"Reminder","sendAt":"` +
+			sendAt.Format(time.RFC3339Nano) + `"}`
+		rec := call(srv, http.MethodPost, "/v3/email", body)
+		if rec.Code != http.StatusAccepted {
+			t.Fatalf("Expected 202, got %d: %s", rec.Code, rec.Body)
+		}
+		var resp struct {
+			MessageID  string `json:"messageId"`
+			Recipients []struct{ Status string }
+		}
+		json.Unmarshal(rec.Body.Bytes(), &resp)
+		if len(resp.Recipients) != 1 || resp.Recipients[0].Status != "SCHEDULED" {
+			t.Fatalf("Expected a scheduled recipient, got %s", rec.Body)
+		}
+		return url.PathEscape(resp.MessageID)
+	}
+	status := func(id string) string {
+		var resp struct{ Recipients []struct{ Status string } }
+		json.Unmarshal(call(srv, http.MethodGet, "/v3/messages/"+id, "").Body.Bytes(), &resp)
+		if len(resp.Recipients) != 1 {
+			return ""
+		}
+		return resp.Recipients[0].Status
+	}
+
+	// 1. Scheduling too far ahead is rejected
+	body := `{"from":{"address":"app@example.com"},"to":"user@example.com","subject":"Later","sendAt":"2999-01-01T00:00:00Z"}`
+	if rec := call(srv, http.MethodPost, "/v3/email", body); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a sendAt years ahead, got %d", rec.Code)
+	}
+
+	// 2. Cancel, also after a restart over the same storage
+	later := schedule(time.Now().Add(time.Hour))
+	if got := status(later); got != "SCHEDULED" {
+		t.Errorf("Expected SCHEDULED, got %q", got)
+	}
+	restarted, err := server.New(cfg, server.WithEmailProvider(email), server.WithStorage(srv.Storage()), server.WithLogger(quiet))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if rec := call(restarted, http.MethodDelete, "/v3/messages/"+later, ""); rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	if rec := call(srv, http.MethodDelete, "/v3/messages/"+later, ""); rec.Code != http.StatusConflict {
+		t.Errorf("Expected 409 for a cancelled message, got %d: %s", rec.Code, rec.Body)
+	}
+	if got := status(later); got != "CANCELLED" {
+		t.Errorf("Expected CANCELLED, got %q", got)
+	}
+
+	// 3. Due messages are sent by Run
+	soon := schedule(time.Now().Add(500 * time.Millisecond))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go srv.Run(ctx)
+	deadline := time.Now().Add(5 * time.Second)
+	for status(soon) != "SENT" {
+		if time.Now().After(deadline) {
+			t.Fatalf("Scheduled email was not sent, status %q", status(soon))
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	email.mu.Lock()
+	defer email.mu.Unlock()
+	if len(email.sent) != 1 || email.sent[0] != "app@example.com -> user@example.com: Reminder" {
+		t.Errorf("Unexpected deliveries: %v", email.sent)
+	}
+	if rec := call(srv, http.MethodDelete, "/v3/messages/"+soon, ""); rec.Code != http.StatusConflict {
+		t.Errorf("Expected 409 for a sent message, got %d", rec.Code)
+	}
+}
+
+func TestServer_ScheduledMessagesSurviveRestart(t *testing.T) {
+	t.Parallel()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	port := l.Addr().(*net.TCPAddr).Port
+	l.Close()
+
+	cfg, priv := newConfig(t)
+	cfg.Server.Host = "127.0.0.1"
+	cfg.Server.Port = port
+	cfg.Storage = server.StorageConfig{Driver: "bolt", Path: t.TempDir() + "/mds.db"}
+	email := &fakeEmail{}
+	start := func() (*server.Server, func()) {
+		srv, err := server.New(cfg, server.WithEmailProvider(email), server.WithLogger(quiet))
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() { done <- srv.Run(ctx) }()
+		return srv, func() {
+			cancel()
+			if err := <-done; err != nil {
+				t.Fatalf("Run failed: %v", err)
+			}
+		}
+	}
+	call := func(srv *server.Server, method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		sign(priv, req, body)
+		rec := httptest.NewRecorder()
+		srv.Handler().ServeHTTP(rec, req)
+		return rec
+	}
+
+	// 1. Schedule, then stop the server, which closes the storage
+	srv, stop := start()
+	body := `{"from":{"address":"app@example.com"},"to":"user@example.com","subject":"Reminder","sendAt":"` +
+		time.Now().Add(2*time.Second).Format(time.RFC3339Nano) + `"}`
+	rec := call(srv, http.MethodPost, "/v3/email", body)
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("Expected 202, got %d: %s", rec.Code, rec.Body)
+	}
+	var resp struct {
+		MessageID string `json:"messageId"`
+	}
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	id := url.PathEscape(resp.MessageID)
+	stop()
+
+	// 2. A new server over the same file sends it when due
+	srv, stop = start()
+	defer stop()
+	status := func() string {
+		var resp struct{ Recipients []struct{ Status string } }
+		json.Unmarshal(call(srv, http.MethodGet, "/v3/messages/"+id, "").Body.Bytes(), &resp)
+		if len(resp.Recipients) != 1 {
+			return ""
+		}
+		return resp.Recipients[0].Status
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for status() != "SENT" {
+		if time.Now().After(deadline) {
+			t.Fatalf("Scheduled email was not sent after the restart, status %q", status())
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	email.mu.Lock()
+	defer email.mu.Unlock()
+	if len(email.sent) != 1 || email.sent[0] != "app@example.com -> user@example.com: Reminder" {
+		t.Errorf("Unexpected deliveries: %v", email.sent)
+	}
+}
+
+func TestServer_ScheduledSmsRetriesUnsentNumbers(t *testing.T) {
+	t.Parallel()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	port := l.Addr().(*net.TCPAddr).Port
+	l.Close()
+
+	cfg, priv := newConfig(t)
+	cfg.Server.Host = "127.0.0.1"
+	cfg.Server.Port = port
+	sms := &fakeSms{fail: "+46700000002"}
+	srv, err := server.New(cfg, server.WithSmsProvider(sms), server.WithLogger(quiet))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	call := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		sign(priv, req, body)
+		rec := httptest.NewRecorder()
+		srv.Handler().ServeHTTP(rec, req)
+		return rec
+	}
+	statuses := func(id string) string {
+		var resp struct {
+			Recipients []struct{ Address, Status string }
+		}
+		json.Unmarshal(call(http.MethodGet, "/v3/messages/"+id, "").Body.Bytes(), &resp)
+		var statuses []string
+		for _, r := range resp.Recipients {
+			statuses = append(statuses, r.Address+"="+r.Status)
+		}
+		return strings.Join(statuses, ",")
+	}
+	waitFor := func(id, want string) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for statuses(id) != want {
+			if time.Now().After(deadline) {
+				t.Fatalf("Expected statuses %s, got %s", want, statuses(id))
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+	}
+
+	// 1. The second number fails, so the job is retried later
+	body := `{"senderName":"MyService","content":{"body":"Hi"},"to":["+46700000001","+46700000002"],"sendAt":"` +
+		time.Now().Add(300*time.Millisecond).Format(time.RFC3339Nano) + `"}`
+	rec := call(http.MethodPost, "/v3/sms", body)
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("Expected 202, got %d: %s", rec.Code, rec.Body)
+	}
+	var resp struct {
+		MessageID string `json:"messageId"`
+	}
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	id := url.PathEscape(resp.MessageID)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go srv.Run(ctx)
+	waitFor(id, "+46700000001=SENT,+46700000002=SCHEDULED")
+
+	// 2. Only the unsent number is left to retry or cancel
+	if rec := call(http.MethodDelete, "/v3/messages/"+id, ""); rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	waitFor(id, "+46700000001=SENT,+46700000002=CANCELLED")
+	sms.mu.Lock()
+	defer sms.mu.Unlock()
+	if len(sms.sent) != 1 || sms.sent[0] != "MyService -> +46700000001: Hi" {
+		t.Errorf("Unexpected deliveries: %v", sms.sent)
+	}
+}
+
+func TestServer_RunFailureReleasesStorage(t *testing.T) {
+	t.Parallel()
+
+	// The port is taken, so Run fails right away.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer l.Close()
+
+	cfg, _ := newConfig(t)
+	cfg.Server.Host = "127.0.0.1"
+	cfg.Server.Port = l.Addr().(*net.TCPAddr).Port
+	cfg.Storage = server.StorageConfig{Driver: "bolt", Path: t.TempDir() + "/mds.db"}
+	srv, err := server.New(cfg, server.WithLogger(quiet))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if err := srv.Run(context.Background()); err == nil {
+		t.Fatal("Expected Run to fail on a port in use")
+	}
+
+	// The file is closed, so another server can open it.
+	if _, err := server.New(cfg, server.WithLogger(quiet)); err != nil {
+		t.Errorf("Expected the storage to be released, got %v", err)
+	}
+}
+
+func TestServer_QuietHours(t *testing.T) {
+	t.Parallel()
+
+	// A two-hour window around now in UTC, which is 12 hours away from UTC+12.
+	now := time.Now().UTC()
+	cfg, priv := newConfig(t)
+	cfg.Services[0].QuietHours = server.QuietHoursConfig{
+		Start: now.Add(-time.Hour).Format("15:04"),
+		End:   now.Add(time.Hour).Format("15:04"),
+	}
+	sms := &fakeSms{}
+	srv, err := server.New(cfg, server.WithSmsProvider(sms), server.WithLogger(quiet))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	send := func(body string) (int, string) {
+		req := httptest.NewRequest(http.MethodPost, "/v3/sms", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		sign(priv, req, body)
+		rec := httptest.NewRecorder()
+		srv.Handler().ServeHTTP(rec, req)
+		var resp struct {
+			MessageID  string `json:"messageId"`
+			Recipients []struct{ Address, Status, Detail string }
+		}
+		json.Unmarshal(rec.Body.Bytes(), &resp)
+		statuses := []string{resp.MessageID}
+		for _, r := range resp.Recipients {
+			statuses = append(statuses, r.Address+"="+r.Status+" "+r.Detail)
+		}
+		return rec.Code, strings.Join(statuses, ",")
+	}
+
+	// 1. Recipients in quiet hours are deferred, the others are sent by the scheduler
+	code, got := send(`{"senderName":"MyService","content":{"body":"Sale!"},"to":["+46700000000",` +
+		`{"phone":"+64210000000","country":"NZ","timeZone":"Etc/GMT-12"}]}`)
+	if code != http.StatusAccepted {
+		t.Fatalf("Expected 202, got %d: %s", code, got)
+	}
+	if !strings.Contains(got, ",+46700000000=SCHEDULED deferred by quiet hours until ") ||
+		!strings.HasSuffix(got, ",+64210000000=SCHEDULED ") || strings.HasPrefix(got, ",") {
+		t.Errorf("Unexpected statuses: %s", got)
+	}
+	if len(sms.sent) != 0 {
+		t.Errorf("Expected nothing to be sent during the request, got %v", sms.sent)
+	}
+
+	// 2. OTP messages bypass quiet hours
+	if code, got := send(`{"senderName":"MyService","class":"otp","content":{"body":"Code 1234"},"to":"+46700000000"}`); code != http.StatusAccepted || got != ",+46700000000=SENT " {
+		t.Errorf("Expected the OTP to be sent, got %d: %s", code, got)
+	}
+
+	// 3. Unknown time zones are rejected
+	if code, _ := send(`{"senderName":"MyService","to":{"phone":"+46700000000","country":"SE","timeZone":"Mars/Olympus"}}`); code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an unknown time zone, got %d", code)
+	}
+}
+
+func TestServer_TracesRequests(t *testing.T) {
+	t.Parallel()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	cfg, priv := newConfig(t)
+	srv, err := server.New(cfg, server.WithEmailProvider(&fakeEmail{}), server.WithLogger(quiet), server.WithTracerProvider(tp))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	body := `{"from":{"address":"app@example.com"},"to":"user@example.com","subject":"Hello","content":{"template":{"name":"welcome","data":{}}}}`
+	req := httptest.NewRequest(http.MethodPost, "/v3/email", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	sign(priv, req, body)
+	srv.Handler().ServeHTTP(httptest.NewRecorder(), req)
+
+	var names []string
+	for _, span := range recorder.Ended() {
+		if span.SpanContext().TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+			t.Errorf("Span %q is not part of the caller's trace", span.Name())
+		}
+		names = append(names, span.Name())
+	}
+	want := []string{"auth", "render template", "queue email", "handle email", "POST /v3/email"}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected spans %v, got %v", want, names)
+	}
+}
+
+func TestServer_HealthEndpoints(t *testing.T) {
+	t.Parallel()
+
+	cfg, _ := newConfig(t)
+	srv, err := server.New(cfg, server.WithEmailProvider(&probedEmail{err: errors.New("connection refused")}), server.WithLogger(quiet))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health/live", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected 200 from /health/live, got %d: %s", rec.Code, rec.Body)
+	}
+
+	rec = httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected 503 from /health/ready, got %d: %s", rec.Code, rec.Body)
+	}
+	var report struct {
+		Status string
+		Checks []struct{ Name, Status, Error string }
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+		t.Fatalf("Failed to decode report: %v", err)
+	}
+	if report.Status != "not_ready" || len(report.Checks) != 1 ||
+		report.Checks[0].Name != "relay" || report.Checks[0].Status != "down" || report.Checks[0].Error != "connection refused" {
+		t.Errorf("Unexpected report: %s", rec.Body)
+	}
+}
+
+func TestServer_RejectsInvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	cfg := &server.Config{Services: []server.ServiceConfig{{ID: "broken", PublicKey: "nope"}}}
+	if _, err := server.New(cfg); err == nil {
+		t.Fatal("Expected New to reject an invalid public key")
+	}
+	if _, err := server.New(nil); err == nil {
+		t.Fatal("Expected New to require a config")
+	}
+}
+
+func TestServer_Run(t *testing.T) {
+	t.Parallel()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	port := l.Addr().(*net.TCPAddr).Port
+	l.Close()
+
+	cfg, _ := newConfig(t)
+	cfg.Server.Host = "127.0.0.1"
+	cfg.Server.Port = port
+	srv, err := server.New(cfg, server.WithLogger(quiet))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- srv.Run(ctx) }()
+
+	url := fmt.Sprintf("http://127.0.0.1:%d/health", port)
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		resp, err := http.Get(url)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("Expected 200 from /health, got %d", resp.StatusCode)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Server did not come up: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Run returned error: %v", err)
+		}
+	case <-time.After(server.ShutdownTimeout + time.Second):
+		t.Fatal("Run did not return after cancellation")
+	}
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Low-Stack-Technologies/message-delivery-service/internal/config"
+)
+
+// runValidate implements `mds validate [config.yaml ...]`. It checks each file
+// exactly like a (re)load would, without starting the server or creating a
+// default config, and returns the process exit code.
+func runValidate(paths []string) int {
+	if len(paths) == 0 {
+		paths = []string{"config.yaml"}
+	}
+
+	status := 0
+	for _, path := range paths {
+		cfg, err := config.Read(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+			status = 1
+			continue
+		}
+		fmt.Printf("%s: OK (sha256 %s)\n", path, cfg.Version().Hash)
+	}
+	return status
+}